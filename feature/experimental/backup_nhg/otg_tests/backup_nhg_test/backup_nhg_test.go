@@ -274,7 +274,7 @@ func TestIndirectBackupNexthopGroup(t *testing.T) {
 
 	// Delete indirect(recursive) next hop prefix entry to activate the backup
 	// next hop path.
-	c.Modify().DeleteEntry(t, fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	c.Modify().DeleteEntry(t, fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithPrefix("192.0.2.254/32").WithNextHopGroup(10000))
 
 	t.Run("Validate Backup Path Traffic Delivery", func(t *testing.T) {
@@ -316,19 +316,19 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 	p2 := dut.Port(t, "port2")
 	p3 := dut.Port(t, "port3")
 
-	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name()))
-	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name()))
-	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name()))
+	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name(), dut))
 
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(dut).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, p1)
 		fptest.SetPortSpeed(t, p2)
 		fptest.SetPortSpeed(t, p3)
 	}
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, p1.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p2.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p3.Name(), *deviations.DefaultNetworkInstance, 0)
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p3.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
 }
 
@@ -345,17 +345,17 @@ func (a *testArgs) configureBackupNextHopGroup(t *testing.T, del bool) {
 		dutPort2ID, dutPort3ID = 10002, 10003
 	)
 
-	nh1 := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	nh1 := fluent.NextHopEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithIndex(dutPort2ID).WithIPAddress(atePort2.IPv4)
-	nh2 := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	nh2 := fluent.NextHopEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithIndex(dutPort3ID).WithIPAddress(atePort3.IPv4)
 
-	nhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	nhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithID(dstNHGID).AddNextHop(dutPort2ID, 1).WithBackupNHG(dstBackupNHGID)
-	bnhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	bnhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithID(dstBackupNHGID).AddNextHop(dutPort3ID, 1)
 
-	pfx := fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	pfx := fluent.IPv4Entry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithPrefix(dstPfx).WithNextHopGroup(dstNHGID)
 
 	if del {
@@ -389,23 +389,23 @@ func (a *testArgs) configureIndirBackupNextHopGroup(t *testing.T, del bool) {
 		dutPort2ID, dutPort3ID = 10002, 10003
 	)
 
-	rnh := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	rnh := fluent.NextHopEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithIndex(recurNHID).WithIPAddress(recurNH)
-	nhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	nhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithID(dstNHGID).AddNextHop(recurNHID, 1).WithBackupNHG(dstBackupNHGID)
-	pfx := fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	pfx := fluent.IPv4Entry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithPrefix(dstPfx).WithNextHopGroup(dstNHGID)
 
-	nh1 := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	nh1 := fluent.NextHopEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithIndex(dutPort2ID).WithIPAddress(atePort2.IPv4)
-	rnhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	rnhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithID(recurNHGID).AddNextHop(dutPort2ID, 1)
-	rpfx := fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	rpfx := fluent.IPv4Entry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithPrefix(recurPfx).WithNextHopGroup(recurNHGID)
 
-	nh2 := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	nh2 := fluent.NextHopEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithIndex(dutPort3ID).WithIPAddress(atePort3.IPv4)
-	bnhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	bnhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).
 		WithID(dstBackupNHGID).AddNextHop(dutPort3ID, 1)
 
 	if del {
@@ -436,7 +436,7 @@ func (a *testArgs) createFlow(name string, ateTop gosnappi.Config, dst *attrs.At
 }
 
 func (a *testArgs) validateAftTelemetry(t *testing.T) {
-	aftPfxNHG := gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance).Afts().Ipv4Entry(dstPfx).NextHopGroup()
+	aftPfxNHG := gnmi.OC().NetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).Afts().Ipv4Entry(dstPfx).NextHopGroup()
 	aftPfxNHGVal, found := gnmi.Watch(t, a.dut, aftPfxNHG.State(), 10*time.Second, func(val *ygnmi.Value[uint64]) bool {
 
 		return true
@@ -446,13 +446,13 @@ func (a *testArgs) validateAftTelemetry(t *testing.T) {
 	}
 	nhg, _ := aftPfxNHGVal.Val()
 
-	aftNHG := gnmi.Get(t, a.dut, gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance).Afts().NextHopGroup(nhg).State())
+	aftNHG := gnmi.Get(t, a.dut, gnmi.OC().NetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).Afts().NextHopGroup(nhg).State())
 	if got := len(aftNHG.NextHop); got != 1 {
 		t.Fatalf("Prefix %s next-hop entry count: got %d, want 1", dstPfx, got)
 	}
 
 	for k := range aftNHG.NextHop {
-		aftnh := gnmi.Get(t, a.dut, gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance).Afts().NextHop(k).State())
+		aftnh := gnmi.Get(t, a.dut, gnmi.OC().NetworkInstance(deviations.For(a.dut).DefaultNetworkInstance()).Afts().NextHop(k).State())
 		if got, want := aftnh.GetIpAddress(), atePort2.IPv4; got != want {
 			t.Fatalf("Prefix %s next-hop IP: got %s, want %s", dstPfx, got, want)
 		}
@@ -470,12 +470,8 @@ func (a *testArgs) validateTrafficFlows(t *testing.T, ate *ondatra.ATEDevice, co
 
 	otgutils.LogFlowMetrics(t, ate.OTG(), config)
 	otgutils.LogPortMetrics(t, ate.OTG(), config)
-	if got := getLossPct(t, ate, good); got > 0 {
-		t.Errorf("LossPct for flow %s: got %v, want 0", good, got)
-	}
-	if got := getLossPct(t, ate, bad); got < 100 {
-		t.Errorf("LossPct for flow %s: got %v, want 100", bad, got)
-	}
+	otgutils.ExpectNoLoss(t, ate.OTG(), good)
+	otgutils.ExpectLossPercent(t, ate.OTG(), bad, 100, 100)
 
 }
 
@@ -486,17 +482,3 @@ func waitOTGARPEntry(t *testing.T) {
 		return val.IsPresent()
 	}).Await(t)
 }
-
-// getLossPct returns the loss percentage for a given flow
-func getLossPct(t *testing.T, ate *ondatra.ATEDevice, flowName string) uint64 {
-	t.Helper()
-	recvMetric := gnmi.Get(t, ate.OTG(), gnmi.OTG().Flow(flowName).State())
-	txPackets := recvMetric.GetCounters().GetOutPkts()
-	rxPackets := recvMetric.GetCounters().GetInPkts()
-	lostPackets := txPackets - rxPackets
-	if txPackets == 0 {
-		t.Fatalf("Tx packets should be higher than 0 for flow %s", flowName)
-	}
-	lossPct := lostPackets * 100 / txPackets
-	return lossPct
-}
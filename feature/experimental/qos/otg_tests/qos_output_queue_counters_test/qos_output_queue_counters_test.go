@@ -167,10 +167,13 @@ func TestQoSCounters(t *testing.T) {
 
 	time.Sleep(30 * time.Second)
 	t.Logf("Running traffic on DUT interfaces: %s and %s ", dp1.Name(), dp2.Name())
+	sampler := otgutils.NewSampler(ate.OTG(), top).WithDUT(dut, dp1.Name(), dp2.Name())
 	ate.OTG().StartTraffic(t)
-	time.Sleep(10 * time.Second)
+	sampler.Run(t, 10*time.Second, time.Second)
 	ate.OTG().StopTraffic(t)
 	time.Sleep(30 * time.Second)
+	sampler.Sample(t)
+	sampler.WriteArtifacts(t, "traffic_metrics")
 
 	otgutils.LogFlowMetrics(t, ate.OTG(), top)
 	for trafficID, data := range trafficFlows {
@@ -247,7 +250,7 @@ func ConfigureDUTIntf(t *testing.T, dut *ondatra.DUTDevice) {
 		}
 		i.GetOrCreateEthernet()
 		s := i.GetOrCreateSubinterface(0).GetOrCreateIpv4()
-		if *deviations.InterfaceEnabled && !*deviations.IPv4MissingEnabled {
+		if deviations.For(dut).InterfaceEnabled() && !deviations.For(dut).IPv4MissingEnabled() {
 			s.Enabled = ygot.Bool(true)
 		}
 		a := s.GetOrCreateAddress(intf.ipAddr)
@@ -88,7 +88,7 @@ func TestRebootStatus(t *testing.T) {
 	}
 
 	statusReq := &spb.RebootStatusRequest{Subcomponents: []*tpb.Path{}}
-	if !*deviations.GNOIStatusWithEmptySubcomponent {
+	if !deviations.For(dut).GNOIStatusWithEmptySubcomponent() {
 		statusReq.Subcomponents = append(statusReq.Subcomponents, getSubCompPath(t, dut))
 	}
 	for _, tc := range cases {
@@ -160,7 +160,7 @@ func TestCancelReboot(t *testing.T) {
 		t.Fatalf("Failed to request reboot with unexpected err: %v", err)
 	}
 	statusReq := &spb.RebootStatusRequest{Subcomponents: []*tpb.Path{}}
-	if !*deviations.GNOIStatusWithEmptySubcomponent {
+	if !deviations.For(dut).GNOIStatusWithEmptySubcomponent() {
 		statusReq.Subcomponents = append(statusReq.Subcomponents, getSubCompPath(t, dut))
 	}
 	rebootStatus, err := gnoiClient.System().RebootStatus(context.Background(), statusReq)
@@ -199,5 +199,5 @@ func getSubCompPath(t *testing.T, dut *ondatra.DUTDevice) *tpb.Path {
 	if len(controllerCards) == 2 {
 		_, activeRP = components.FindStandbyRP(t, dut, controllerCards)
 	}
-	return components.GetSubcomponentPath(activeRP)
+	return components.GetSubcomponentPath(activeRP, dut)
 }
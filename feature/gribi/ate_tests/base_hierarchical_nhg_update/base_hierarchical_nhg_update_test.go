@@ -126,23 +126,23 @@ func TestBaseHierarchicalNHGUpdate(t *testing.T) {
 
 	gribi.BecomeLeader(t, gribic)
 
-	addInterfaceRoute(ctx, t, gribic, p2ID, dut.Port(t, "port2").Name(), atePort2.IPv4)
-	addDestinationRoute(ctx, t, gribic)
+	addInterfaceRoute(ctx, t, dut, gribic, p2ID, dut.Port(t, "port2").Name(), atePort2.IPv4)
+	addDestinationRoute(ctx, t, dut, gribic)
 
 	validateTrafficFlows(t, ate, []*ondatra.Flow{p2flow}, []*ondatra.Flow{p3flow})
 
-	addInterfaceRoute(ctx, t, gribic, p3ID, dut.Port(t, "port3").Name(), atePort3.IPv4)
+	addInterfaceRoute(ctx, t, dut, gribic, p3ID, dut.Port(t, "port3").Name(), atePort3.IPv4)
 
 	validateTrafficFlows(t, ate, []*ondatra.Flow{p3flow}, []*ondatra.Flow{p2flow})
 }
 
 // addDestinationRoute creates a GRIBI route to dstPfx via interfaceNH.
-func addDestinationRoute(ctx context.Context, t *testing.T, gribic *fluent.GRIBIClient) {
-	dnh := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+func addDestinationRoute(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, gribic *fluent.GRIBIClient) {
+	dnh := fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithIndex(dstNHID).WithIPAddress(interfaceNH)
-	dnhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	dnhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithID(dstNHGID).AddNextHop(dstNHID, 1)
-	dpfx := fluent.IPv4Entry().WithNetworkInstance(vrfName).WithPrefix(dstPfx).WithNextHopGroup(dstNHGID).WithNextHopGroupNetworkInstance(*deviations.DefaultNetworkInstance)
+	dpfx := fluent.IPv4Entry().WithNetworkInstance(vrfName).WithPrefix(dstPfx).WithNextHopGroup(dstNHGID).WithNextHopGroupNetworkInstance(deviations.For(dut).DefaultNetworkInstance())
 
 	gribic.Modify().AddEntry(t, dnh, dnhg, dpfx)
 	if err := awaitTimeout(ctx, gribic, t, time.Minute); err != nil {
@@ -174,12 +174,12 @@ func addDestinationRoute(ctx context.Context, t *testing.T, gribic *fluent.GRIBI
 
 // addInterfaceRoute creates a GRIBI route that points to the egress interface defined by id,
 // port, and nhip.
-func addInterfaceRoute(ctx context.Context, t *testing.T, gribic *fluent.GRIBIClient, id uint64, port string, nhip string) {
-	inh := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+func addInterfaceRoute(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, gribic *fluent.GRIBIClient, id uint64, port string, nhip string) {
+	inh := fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithIndex(id).WithInterfaceRef(port).WithIPAddress(nhip).WithMacAddress(pMAC)
-	inhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	inhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithID(interfaceID).AddNextHop(id, 1)
-	ipfx := fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	ipfx := fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithPrefix(interfacePfx).WithNextHopGroup(interfaceID)
 
 	gribic.Modify().AddEntry(t, inh, inhg, ipfx)
@@ -250,18 +250,18 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 	p1VRF.Subinterface = ygot.Uint32(0)
 	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(vrfName).Config(), vrf)
 
-	gnmi.Update(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name()))
-	gnmi.Update(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name()))
-	gnmi.Update(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name()))
+	gnmi.Update(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Update(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	gnmi.Update(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name(), dut))
 
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(dut).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, p1)
 		fptest.SetPortSpeed(t, p2)
 		fptest.SetPortSpeed(t, p3)
 	}
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, p2.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p3.Name(), *deviations.DefaultNetworkInstance, 0)
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p3.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
 }
 
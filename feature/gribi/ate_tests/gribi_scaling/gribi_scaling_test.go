@@ -26,6 +26,7 @@ import (
 	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/fptest"
 	"github.com/openconfig/featureprofiles/internal/gribi"
+	"github.com/openconfig/featureprofiles/internal/networkinstance"
 	"github.com/openconfig/gribigo/chk"
 	"github.com/openconfig/gribigo/constants"
 	"github.com/openconfig/gribigo/fluent"
@@ -164,7 +165,7 @@ func installEntries(t *testing.T, ips []string, nexthops []string, index routesP
 		ind := uint64(index.nhID + i)
 		if index.vrf == "vrf3" {
 			nh := fluent.NextHopEntry().
-				WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				WithNetworkInstance(deviations.For(args.dut).DefaultNetworkInstance()).
 				WithIndex(ind).
 				WithIPinIP(tunnelSrcIP, tunnelDstIP).
 				WithDecapsulateHeader(fluent.IPinIP).
@@ -174,7 +175,7 @@ func installEntries(t *testing.T, ips []string, nexthops []string, index routesP
 			args.client.Modify().AddEntry(t, nh)
 		} else {
 			nh := fluent.NextHopEntry().
-				WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				WithNetworkInstance(deviations.For(args.dut).DefaultNetworkInstance()).
 				WithIndex(ind).
 				WithIPAddress(ateAddr).
 				WithElectionID(args.electionID.Low, args.electionID.High)
@@ -182,7 +183,7 @@ func installEntries(t *testing.T, ips []string, nexthops []string, index routesP
 		}
 
 		nhg := fluent.NextHopGroupEntry().
-			WithNetworkInstance(*deviations.DefaultNetworkInstance).
+			WithNetworkInstance(deviations.For(args.dut).DefaultNetworkInstance()).
 			WithID(uint64(localIndex)).
 			AddNextHop(ind, uint64(index.maxNhCount)).
 			WithElectionID(args.electionID.Low, args.electionID.High)
@@ -208,7 +209,7 @@ func installEntries(t *testing.T, ips []string, nexthops []string, index routesP
 				WithPrefix(ips[ip]+"/32").
 				WithNetworkInstance(index.vrf).
 				WithNextHopGroup(uint64(localIndex)).
-				WithNextHopGroupNetworkInstance(*deviations.DefaultNetworkInstance))
+				WithNextHopGroupNetworkInstance(deviations.For(args.dut).DefaultNetworkInstance()))
 		nextCount = nextCount + 1
 		if nextCount == index.maxIPCount {
 			localIndex = localIndex + 1
@@ -249,14 +250,14 @@ func pushDefaultEntries(t *testing.T, args *testArgs, nextHops []string) []strin
 		index := uint64(i + 1)
 		args.client.Modify().AddEntry(t,
 			fluent.NextHopEntry().
-				WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				WithNetworkInstance(deviations.For(args.dut).DefaultNetworkInstance()).
 				WithIndex(index).
 				WithIPAddress(nextHops[i]).
 				WithElectionID(args.electionID.Low, args.electionID.High))
 
 		args.client.Modify().AddEntry(t,
 			fluent.NextHopGroupEntry().
-				WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				WithNetworkInstance(deviations.For(args.dut).DefaultNetworkInstance()).
 				WithID(uint64(2)).
 				AddNextHop(index, 64).
 				WithElectionID(args.electionID.Low, args.electionID.High))
@@ -268,7 +269,7 @@ func pushDefaultEntries(t *testing.T, args *testArgs, nextHops []string) []strin
 		args.client.Modify().AddEntry(t,
 			fluent.IPv4Entry().
 				WithPrefix(virtualVIPs[ip]+"/32").
-				WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				WithNetworkInstance(deviations.For(args.dut).DefaultNetworkInstance()).
 				WithNextHopGroup(uint64(2)).
 				WithElectionID(args.electionID.Low, args.electionID.High))
 	}
@@ -294,11 +295,11 @@ func createVrf(t *testing.T, dut *ondatra.DUTDevice, d *oc.Root, vrfs []string)
 	for _, vrf := range vrfs {
 		// For non-default VRF, we want to replace the
 		// entire VRF tree so the instance is created.
-		if vrf != *deviations.DefaultNetworkInstance {
-			i := d.GetOrCreateNetworkInstance(vrf)
-			i.Type = oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF
-			i.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, *deviations.StaticProtocolName)
-			gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(vrf).Config(), i)
+		if b := networkinstance.L3VRF(dut, vrf); !b.IsDefault() {
+			b.WithStaticProtocol().Push(t, dut)
+			if err := d.AppendNetworkInstance(b.Build()); err != nil {
+				t.Fatalf("AppendNetworkInstance(%q) got error: %v", vrf, err)
+			}
 			nip := gnmi.OC().NetworkInstance(vrf)
 			fptest.LogQuery(t, "nonDefaultNI", nip.Config(), gnmi.GetConfig(t, dut, nip.Config()))
 		}
@@ -313,22 +314,22 @@ func pushConfig(t *testing.T, dut *ondatra.DUTDevice, dutPort *ondatra.Port, d *
 	iname := dutPort.Name()
 	i := d.GetOrCreateInterface(iname)
 	gnmi.Replace(t, dut, gnmi.OC().Interface(iname).Config(), i)
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(dut).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, dutPort)
 	}
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, i.GetName(), *deviations.DefaultNetworkInstance, 0)
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, i.GetName(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
 }
 
 // configureInterfaceDUT configures a single DUT layer 2 port.
-func configureInterfaceDUT(t *testing.T, dutPort *ondatra.Port, d *oc.Root, desc string) {
+func configureInterfaceDUT(t *testing.T, dut *ondatra.DUTDevice, dutPort *ondatra.Port, d *oc.Root, desc string) {
 	t.Helper()
 
 	i := d.GetOrCreateInterface(dutPort.Name())
 	i.Description = ygot.String(desc)
 	i.Type = oc.IETFInterfaces_InterfaceType_ethernetCsmacd
-	if *deviations.InterfaceEnabled {
+	if deviations.For(dut).InterfaceEnabled() {
 		i.Enabled = ygot.Bool(true)
 	}
 	t.Logf("DUT port %s configured", dutPort)
@@ -346,17 +347,17 @@ func generateSubIntfPair(t *testing.T, dut *ondatra.DUTDevice, dutPort *ondatra.
 		Index := uint32(i)
 		ateIPv4 := fmt.Sprintf(`198.51.100.%d`, ((4 * i) + 1))
 		dutIPv4 := fmt.Sprintf(`198.51.100.%d`, ((4 * i) + 2))
-		configureSubinterfaceDUT(t, d, dutPort, Index, vlanID, dutIPv4, *deviations.DefaultNetworkInstance)
+		configureSubinterfaceDUT(t, dut, d, dutPort, Index, vlanID, dutIPv4, deviations.For(dut).DefaultNetworkInstance())
 		configureATE(t, top, atePort, name, vlanID, dutIPv4, ateIPv4+"/30")
 		nextHops = append(nextHops, ateIPv4)
 	}
-	configureInterfaceDUT(t, dutPort, d, "dst")
+	configureInterfaceDUT(t, dut, dutPort, d, "dst")
 	pushConfig(t, dut, dutPort, d)
 	return nextHops
 }
 
 // configureSubinterfaceDUT configures a single DUT layer 3 sub-interface.
-func configureSubinterfaceDUT(t *testing.T, d *oc.Root, dutPort *ondatra.Port, index uint32, vlanID uint16, dutIPv4 string, vrf string) {
+func configureSubinterfaceDUT(t *testing.T, dut *ondatra.DUTDevice, d *oc.Root, dutPort *ondatra.Port, index uint32, vlanID uint16, dutIPv4 string, vrf string) {
 	t.Helper()
 	if vrf != "" {
 		t.Logf("Put port %s into vrf %s", dutPort.Name(), vrf)
@@ -366,7 +367,7 @@ func configureSubinterfaceDUT(t *testing.T, d *oc.Root, dutPort *ondatra.Port, i
 	i := d.GetOrCreateInterface(dutPort.Name())
 	s := i.GetOrCreateSubinterface(index)
 	if vlanID != 0 {
-		if *deviations.DeprecatedVlanID {
+		if deviations.For(dut).DeprecatedVlanID() {
 			s.GetOrCreateVlan().VlanId = oc.UnionUint16(vlanID)
 		} else {
 			s.GetOrCreateVlan().GetOrCreateMatch().GetOrCreateSingleTagged().VlanId = ygot.Uint16(vlanID)
@@ -375,7 +376,7 @@ func configureSubinterfaceDUT(t *testing.T, d *oc.Root, dutPort *ondatra.Port, i
 
 	sipv4 := s.GetOrCreateIpv4()
 
-	if *deviations.InterfaceEnabled && !*deviations.IPv4MissingEnabled {
+	if deviations.For(dut).InterfaceEnabled() && !deviations.For(dut).IPv4MissingEnabled() {
 		sipv4.Enabled = ygot.Bool(true)
 	}
 
@@ -423,11 +424,11 @@ func TestScaling(t *testing.T) {
 	dp1 := dut.Port(t, "port1")
 	ap1 := ate.Port(t, "port1")
 	top := ate.Topology().New()
-	vrfs := []string{*deviations.DefaultNetworkInstance, vrf1, vrf2, vrf3}
+	vrfs := []string{deviations.For(dut).DefaultNetworkInstance(), vrf1, vrf2, vrf3}
 	createVrf(t, dut, d, vrfs)
 	// configure an L3 subinterface of no vlan tagging under DUT port#1
-	configureSubinterfaceDUT(t, d, dp1, 0, 0, dutPort1.IPv4, vrf1)
-	configureInterfaceDUT(t, dp1, d, "src")
+	configureSubinterfaceDUT(t, dut, d, dp1, 0, 0, dutPort1.IPv4, vrf1)
+	configureInterfaceDUT(t, dut, dp1, d, "src")
 	configureATE(t, top, ap1, "src", 0, dutPort1.IPv4, atePort1.IPv4CIDR())
 	pushConfig(t, dut, dp1, d)
 	dp2 := dut.Port(t, "port2")
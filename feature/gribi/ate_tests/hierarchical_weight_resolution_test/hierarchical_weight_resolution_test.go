@@ -227,23 +227,23 @@ func awaitTimeout(ctx context.Context, c *fluent.GRIBIClient, t testing.TB, time
 // starting from Sub Interface 1. Each Subinterface is configured with a
 // unique VlanID starting from 1 and an IP address. The starting IP Address
 // for Subinterface(1) = dutPort.ip(1) = dutPort.ip + 4
-func (a *attributes) configSubinterfaceDUT(t *testing.T, intf *oc.Interface) {
+func (a *attributes) configSubinterfaceDUT(t *testing.T, d *ondatra.DUTDevice, intf *oc.Interface) {
 	t.Helper()
 
 	for i := uint32(1); i <= a.numSubIntf; i++ {
 		ip := a.ip(uint8(i))
 
 		s := intf.GetOrCreateSubinterface(i)
-		if *deviations.InterfaceEnabled {
+		if deviations.For(d).InterfaceEnabled() {
 			s.Enabled = ygot.Bool(true)
 		}
-		if *deviations.DeprecatedVlanID {
+		if deviations.For(d).DeprecatedVlanID() {
 			s.GetOrCreateVlan().VlanId = oc.UnionUint16(i)
 		} else {
 			s.GetOrCreateVlan().GetOrCreateMatch().GetOrCreateSingleTagged().VlanId = ygot.Uint16(uint16(i))
 		}
 		s4 := s.GetOrCreateIpv4()
-		if *deviations.InterfaceEnabled && !*deviations.IPv4MissingEnabled {
+		if deviations.For(d).InterfaceEnabled() && !deviations.For(d).IPv4MissingEnabled() {
 			s4.Enabled = ygot.Bool(true)
 		}
 		s4a := s4.GetOrCreateAddress(ip)
@@ -256,12 +256,12 @@ func (a *attributes) configSubinterfaceDUT(t *testing.T, intf *oc.Interface) {
 // Sub Interfaces are also configured if numSubIntf > 0.
 func (a *attributes) configInterfaceDUT(t *testing.T, d *ondatra.DUTDevice, p *ondatra.Port) {
 	t.Helper()
-	i := a.NewOCInterface(p.Name())
+	i := a.NewOCInterface(p.Name(), d)
 
-	a.configSubinterfaceDUT(t, i)
+	a.configSubinterfaceDUT(t, d, i)
 	intfPath := gnmi.OC().Interface(p.Name())
 	gnmi.Update(t, d, intfPath.Config(), i)
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(d).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, p)
 	}
 	fptest.LogQuery(t, "DUT", intfPath.Config(), gnmi.GetConfig(t, d, intfPath.Config()))
@@ -285,8 +285,8 @@ func (a *attributes) configureNetworkInstance(t *testing.T, d *ondatra.DUTDevice
 		gnmi.Replace(t, d, dni.Config(), ni)
 		fptest.LogQuery(t, "NI", dni.Config(), gnmi.GetConfig(t, d, dni.Config()))
 	} else {
-		if *deviations.ExplicitInterfaceInDefaultVRF {
-			dni := gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance)
+		if deviations.For(d).ExplicitInterfaceInDefaultVRF() {
+			dni := gnmi.OC().NetworkInstance(deviations.For(d).DefaultNetworkInstance())
 			gnmi.Replace(t, d, dni.Interface(p.Name()).Config(), &oc.NetworkInstance_Interface{
 				Id:           ygot.String(p.Name()),
 				Interface:    ygot.String(p.Name()),
@@ -414,7 +414,7 @@ func aftNextHopWeights(t *testing.T, dut *ondatra.DUTDevice, nhg uint64, network
 // testBasicHierarchicalWeight tests and validates traffic through 4 Vlans.
 func testBasicHierarchicalWeight(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice,
 	ate *ondatra.ATEDevice, top *ondatra.ATETopology, gRIBI *fluent.GRIBIClient) {
-	defaultVRF := *deviations.DefaultNetworkInstance
+	defaultVRF := deviations.For(dut).DefaultNetworkInstance()
 
 	// Set up NH#10, NH#11, NHG#2, IPv4Entry(192.0.2.111).
 	nh10 := nextHopEntry(10, defaultVRF, atePort2.ip(1))
@@ -492,7 +492,7 @@ func testBasicHierarchicalWeight(ctx context.Context, t *testing.T, dut *ondatra
 // testHierarchicalWeightBoundaryScenario tests and validates traffic through all 18 Vlans.
 func testHierarchicalWeightBoundaryScenario(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice,
 	ate *ondatra.ATEDevice, top *ondatra.ATETopology, gRIBI *fluent.GRIBIClient) {
-	defaultVRF := *deviations.DefaultNetworkInstance
+	defaultVRF := deviations.For(dut).DefaultNetworkInstance()
 
 	// Set up NH#10, NH#11, NHG#2, IPv4Entry(192.0.2.111).
 	nh10 := nextHopEntry(10, defaultVRF, atePort2.ip(1))
@@ -121,11 +121,11 @@ func TestIPv4Entry(t *testing.T) {
 		{
 			desc: "Single next-hop",
 			entries: []fluent.GRIBIEntry{
-				fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithIndex(nh1ID).WithIPAddress(atePort2.IPv4),
-				fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithID(nhgID).AddNextHop(nh1ID, 1),
-				fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithPrefix(dstPfx).WithNextHopGroup(nhgID),
 			},
 			wantGoodFlows: []*ondatra.Flow{port2Flow},
@@ -151,13 +151,13 @@ func TestIPv4Entry(t *testing.T) {
 		{
 			desc: "Multiple next-hops",
 			entries: []fluent.GRIBIEntry{
-				fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithIndex(nh1ID).WithIPAddress(atePort2.IPv4),
-				fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithIndex(nh2ID).WithIPAddress(atePort3.IPv4),
-				fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithID(nhgID).AddNextHop(nh1ID, 1).AddNextHop(nh2ID, 1),
-				fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithPrefix(dstPfx).WithNextHopGroup(nhgID),
 			},
 			wantGoodFlows: []*ondatra.Flow{ecmpFlow},
@@ -187,9 +187,9 @@ func TestIPv4Entry(t *testing.T) {
 		{
 			desc: "Nonexistant next-hop",
 			entries: []fluent.GRIBIEntry{
-				fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithID(nhgID).AddNextHop(badNH, 1),
-				fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithPrefix(dstPfx).WithNextHopGroup(nhgID),
 			},
 			wantBadFlows: []*ondatra.Flow{port2Flow, port3Flow},
@@ -210,12 +210,12 @@ func TestIPv4Entry(t *testing.T) {
 			desc:     "Downed next-hop interface",
 			downPort: ate.Port(t, "port2"),
 			entries: []fluent.GRIBIEntry{
-				fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithIndex(nh1ID).WithIPAddress(atePort2.IPv4).
 					WithInterfaceRef(dut.Port(t, "port2").Name()).WithMacAddress(badMAC),
-				fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithID(nhgID).AddNextHop(nh1ID, 1),
-				fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+				fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 					WithPrefix(dstPfx).WithNextHopGroup(nhgID),
 			},
 			wantBadFlows: []*ondatra.Flow{port2Flow, port3Flow},
@@ -247,7 +247,7 @@ func TestIPv4Entry(t *testing.T) {
 	// Each case will run with its own gRIBI fluent client.
 	for _, persist := range []string{usePreserve, useDelete} {
 		t.Run(fmt.Sprintf("Persistence=%s", persist), func(t *testing.T) {
-			if *deviations.GRIBIPreserveOnly && persist == useDelete {
+			if deviations.For(dut).GRIBIPreserveOnly() && persist == useDelete {
 				t.Skip("Skipping due to --deviation_gribi_preserve_only")
 			}
 
@@ -263,7 +263,7 @@ func TestIPv4Entry(t *testing.T) {
 						conn.WithPersistence()
 					}
 
-					if !*deviations.GRIBIRIBAckOnly {
+					if !deviations.For(dut).GRIBIRIBAckOnly() {
 						// The main difference WithFIBACK() made was that we are now expecting
 						// fluent.InstalledInFIB in []*client.OpResult, as opposed to
 						// fluent.InstalledInRIB.
@@ -327,19 +327,19 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 	p2 := dut.Port(t, "port2")
 	p3 := dut.Port(t, "port3")
 
-	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name()))
-	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name()))
-	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name()))
+	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name(), dut))
 
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(dut).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, p1)
 		fptest.SetPortSpeed(t, p2)
 		fptest.SetPortSpeed(t, p3)
 	}
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, p1.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p2.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p3.Name(), *deviations.DefaultNetworkInstance, 0)
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p3.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
 }
 
@@ -147,28 +147,28 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 	d := gnmi.OC()
 
 	p1 := dut.Port(t, "port1")
-	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name()))
+	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
 
 	p2 := dut.Port(t, "port2")
-	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name()))
+	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
 
 	p3 := dut.Port(t, "port3")
-	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name()))
+	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name(), dut))
 
 	p4 := dut.Port(t, "port4")
-	gnmi.Replace(t, dut, d.Interface(p4.Name()).Config(), dutPort4.NewOCInterface(p4.Name()))
+	gnmi.Replace(t, dut, d.Interface(p4.Name()).Config(), dutPort4.NewOCInterface(p4.Name(), dut))
 
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(dut).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, p1)
 		fptest.SetPortSpeed(t, p2)
 		fptest.SetPortSpeed(t, p3)
 		fptest.SetPortSpeed(t, p4)
 	}
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, p1.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p2.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p3.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p4.Name(), *deviations.DefaultNetworkInstance, 0)
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p3.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p4.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
 }
 
@@ -252,14 +252,14 @@ func testIPv4BackUpSwitch(ctx context.Context, t *testing.T, args *testArgs) {
 		NH1ID, NH2ID, NH3ID = 1001, 1002, 1003
 	)
 	t.Logf("Program a backup pointing to ATE port-4 via gRIBI")
-	args.client.AddNH(t, NH3ID, atePort4.IPv4, *deviations.DefaultNetworkInstance, fluent.InstalledInRIB)
-	args.client.AddNHG(t, BackupNHGID, map[uint64]uint64{NH3ID: 10}, *deviations.DefaultNetworkInstance, fluent.InstalledInRIB)
+	args.client.AddNH(t, NH3ID, atePort4.IPv4, deviations.For(args.dut).DefaultNetworkInstance(), fluent.InstalledInRIB)
+	args.client.AddNHG(t, BackupNHGID, map[uint64]uint64{NH3ID: 10}, deviations.For(args.dut).DefaultNetworkInstance(), fluent.InstalledInRIB)
 
 	t.Logf("an IPv4Entry for %s pointing to ATE port-2 and port-3 via gRIBI", dstPfx)
-	args.client.AddNH(t, NH1ID, atePort2.IPv4, *deviations.DefaultNetworkInstance, fluent.InstalledInRIB)
-	args.client.AddNH(t, NH2ID, atePort3.IPv4, *deviations.DefaultNetworkInstance, fluent.InstalledInRIB)
-	args.client.AddNHG(t, NHGID, map[uint64]uint64{NH1ID: 80, NH2ID: 20}, *deviations.DefaultNetworkInstance, fluent.InstalledInRIB, &gribi.NHGOptions{BackupNHG: BackupNHGID})
-	args.client.AddIPv4(t, dstPfx, NHGID, *deviations.DefaultNetworkInstance, *deviations.DefaultNetworkInstance, fluent.InstalledInRIB)
+	args.client.AddNH(t, NH1ID, atePort2.IPv4, deviations.For(args.dut).DefaultNetworkInstance(), fluent.InstalledInRIB)
+	args.client.AddNH(t, NH2ID, atePort3.IPv4, deviations.For(args.dut).DefaultNetworkInstance(), fluent.InstalledInRIB)
+	args.client.AddNHG(t, NHGID, map[uint64]uint64{NH1ID: 80, NH2ID: 20}, deviations.For(args.dut).DefaultNetworkInstance(), fluent.InstalledInRIB, &gribi.NHGOptions{BackupNHG: BackupNHGID})
+	args.client.AddIPv4(t, dstPfx, NHGID, deviations.For(args.dut).DefaultNetworkInstance(), deviations.For(args.dut).DefaultNetworkInstance(), fluent.InstalledInRIB)
 
 	// create flow
 	dstMac := gnmi.Get(t, args.ate.OTG(), gnmi.OTG().Interface(atePort1.Name+".Eth").Ipv4Neighbor(dutPort1.IPv4).LinkLayerAddress().State())
@@ -339,7 +339,7 @@ func flapinterface(t *testing.T, dut *ondatra.DUTDevice, port string, action boo
 // aftCheck does ipv4, NHG and NH aft check
 func aftCheck(t testing.TB, dut *ondatra.DUTDevice, prefix string, expectedNH []string) {
 	// check prefix and get NHG ID
-	aftPfxNHG := gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance).Afts().Ipv4Entry(prefix).NextHopGroup()
+	aftPfxNHG := gnmi.OC().NetworkInstance(deviations.For(dut).DefaultNetworkInstance()).Afts().Ipv4Entry(prefix).NextHopGroup()
 	aftPfxNHGVal, found := gnmi.Watch(t, dut, aftPfxNHG.State(), 10*time.Second, func(val *ygnmi.Value[uint64]) bool {
 		return val.IsPresent()
 	}).Await(t)
@@ -349,13 +349,13 @@ func aftCheck(t testing.TB, dut *ondatra.DUTDevice, prefix string, expectedNH []
 	nhg, _ := aftPfxNHGVal.Val()
 
 	// using NHG ID validate NH
-	aftNHG := gnmi.Get(t, dut, gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance).Afts().NextHopGroup(nhg).State())
+	aftNHG := gnmi.Get(t, dut, gnmi.OC().NetworkInstance(deviations.For(dut).DefaultNetworkInstance()).Afts().NextHopGroup(nhg).State())
 	if got := len(aftNHG.NextHop); got < 1 && aftNHG.BackupNextHopGroup == nil {
 		t.Fatalf("Prefix %s reachability didn't switch to backup path", prefix)
 	}
 	if len(aftNHG.NextHop) != 0 {
 		for k := range aftNHG.NextHop {
-			aftnh := gnmi.Get(t, dut, gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance).Afts().NextHop(k).State())
+			aftnh := gnmi.Get(t, dut, gnmi.OC().NetworkInstance(deviations.For(dut).DefaultNetworkInstance()).Afts().NextHop(k).State())
 			totalIPs := len(expectedNH)
 			for _, ip := range expectedNH {
 				if ip == aftnh.GetIpAddress() {
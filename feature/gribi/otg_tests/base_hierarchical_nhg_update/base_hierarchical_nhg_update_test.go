@@ -137,24 +137,24 @@ func TestBaseHierarchicalNHGUpdate(t *testing.T) {
 
 	gribi.BecomeLeader(t, gribic)
 
-	addInterfaceRoute(ctx, t, gribic, p2ID, dut.Port(t, "port2").Name(), atePort2.IPv4)
-	addDestinationRoute(ctx, t, gribic)
+	addInterfaceRoute(ctx, t, dut, gribic, p2ID, dut.Port(t, "port2").Name(), atePort2.IPv4)
+	addDestinationRoute(ctx, t, dut, gribic)
 
 	waitOTGARPEntry(t)
 	validateTrafficFlows(t, p2flow, p3flow)
 
-	addInterfaceRoute(ctx, t, gribic, p3ID, dut.Port(t, "port3").Name(), atePort3.IPv4)
+	addInterfaceRoute(ctx, t, dut, gribic, p3ID, dut.Port(t, "port3").Name(), atePort3.IPv4)
 
 	validateTrafficFlows(t, p3flow, p2flow)
 }
 
 // addDestinationRoute creates a GRIBI route to dstPfx via interfaceNH.
-func addDestinationRoute(ctx context.Context, t *testing.T, gribic *fluent.GRIBIClient) {
-	dnh := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+func addDestinationRoute(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, gribic *fluent.GRIBIClient) {
+	dnh := fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithIndex(dstNHID).WithIPAddress(interfaceNH)
-	dnhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	dnhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithID(dstNHGID).AddNextHop(dstNHID, 1)
-	dpfx := fluent.IPv4Entry().WithNetworkInstance(vrfName).WithPrefix(dstPfx).WithNextHopGroup(dstNHGID).WithNextHopGroupNetworkInstance(*deviations.DefaultNetworkInstance)
+	dpfx := fluent.IPv4Entry().WithNetworkInstance(vrfName).WithPrefix(dstPfx).WithNextHopGroup(dstNHGID).WithNextHopGroupNetworkInstance(deviations.For(dut).DefaultNetworkInstance())
 
 	gribic.Modify().AddEntry(t, dnh, dnhg, dpfx)
 	if err := awaitTimeout(ctx, gribic, t, time.Minute); err != nil {
@@ -186,12 +186,12 @@ func addDestinationRoute(ctx context.Context, t *testing.T, gribic *fluent.GRIBI
 
 // addInterfaceRoute creates a GRIBI route that points to the egress interface defined by id,
 // port, and nhip.
-func addInterfaceRoute(ctx context.Context, t *testing.T, gribic *fluent.GRIBIClient, id uint64, port string, nhip string) {
-	inh := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+func addInterfaceRoute(ctx context.Context, t *testing.T, dut *ondatra.DUTDevice, gribic *fluent.GRIBIClient, id uint64, port string, nhip string) {
+	inh := fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithIndex(id).WithInterfaceRef(port).WithIPAddress(nhip).WithMacAddress(pMAC)
-	inhg := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	inhg := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithID(interfaceID).AddNextHop(id, 1)
-	ipfx := fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	ipfx := fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithPrefix(interfacePfx).WithNextHopGroup(interfaceID)
 
 	gribic.Modify().AddEntry(t, inh, inhg, ipfx)
@@ -260,18 +260,18 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 	p1VRF.Subinterface = ygot.Uint32(0)
 	gnmi.Replace(t, dut, gnmi.OC().NetworkInstance(vrfName).Config(), vrf)
 
-	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name()))
-	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name()))
-	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name()))
+	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	gnmi.Replace(t, dut, d.Interface(p3.Name()).Config(), dutPort3.NewOCInterface(p3.Name(), dut))
 
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(dut).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, p1)
 		fptest.SetPortSpeed(t, p2)
 		fptest.SetPortSpeed(t, p3)
 	}
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, p2.Name(), *deviations.DefaultNetworkInstance, 0)
-		fptest.AssignToNetworkInstance(t, dut, p3.Name(), *deviations.DefaultNetworkInstance, 0)
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
+		fptest.AssignToNetworkInstance(t, dut, p3.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
 }
 
@@ -319,28 +319,9 @@ func validateTrafficFlows(t *testing.T, goodFlow, badFlow string) {
 
 	otgutils.LogFlowMetrics(t, otg, config)
 	otgutils.LogPortMetrics(t, otg, config)
-	if got := getLossPct(t, goodFlow); got > 0 {
-		t.Errorf("LossPct for flow %s: got %v, want 0", goodFlow, got)
-	}
-	if got := getLossPct(t, badFlow); got < 100 {
-		t.Errorf("LossPct for flow %s: got %v, want 100", badFlow, got)
-	}
-
-}
+	otgutils.ExpectNoLoss(t, otg, goodFlow)
+	otgutils.ExpectLossPercent(t, otg, badFlow, 100, 100)
 
-// getLossPct returns the loss percentage for a given flow
-func getLossPct(t *testing.T, flowName string) uint64 {
-	t.Helper()
-	otg := ondatra.ATE(t, "ate").OTG()
-	flowStats := gnmi.Get(t, otg, gnmi.OTG().Flow(flowName).State())
-	txPackets := flowStats.GetCounters().GetOutPkts()
-	rxPackets := flowStats.GetCounters().GetInPkts()
-	lostPackets := txPackets - rxPackets
-	if txPackets == 0 {
-		t.Fatalf("Tx packets should be higher than 0 for flow %s", flowName)
-	}
-	lossPct := lostPackets * 100 / txPackets
-	return lossPct
 }
 
 // Waits for an ARP entry to be present for ATE Port1
@@ -163,7 +163,7 @@ func TestRouteRemovelViaFlush(t *testing.T) {
 func testFlushWithDefaultNetworkInstance(ctx context.Context, t *testing.T, args *testArgs) {
 	// Inject an entry into the default network instance pointing to ATE port-2.
 	// clientA is primary client
-	injectEntry(ctx, t, args.clientA, *deviations.DefaultNetworkInstance)
+	injectEntry(ctx, t, args.clientA, deviations.For(args.dut).DefaultNetworkInstance())
 	// Test traffic between ATE port-1 and ATE port-2.
 	lossPct := testTraffic(t, args.ate, args.ateTop)
 	if got := lossPct; got > 0 {
@@ -173,7 +173,7 @@ func testFlushWithDefaultNetworkInstance(ctx context.Context, t *testing.T, args
 	}
 
 	// Flush should delete the entries
-	if _, err := gribi.Flush(args.clientA, args.electionID, *deviations.DefaultNetworkInstance); err != nil {
+	if _, err := gribi.Flush(args.clientA, args.electionID, deviations.For(args.dut).DefaultNetworkInstance()); err != nil {
 		t.Errorf("Unexpected error from flush, got: %v", err)
 	}
 	// After flush, left entry should be 0, and packets can no longer be forwarded.
@@ -183,19 +183,19 @@ func testFlushWithDefaultNetworkInstance(ctx context.Context, t *testing.T, args
 	} else {
 		t.Log("Traffic can not be forwarded between ATE port-1 and ATE port-2")
 	}
-	if got, want := checkNIHasNEntries(ctx, args.clientA, *deviations.DefaultNetworkInstance, t), 0; got != want {
+	if got, want := checkNIHasNEntries(ctx, args.clientA, deviations.For(args.dut).DefaultNetworkInstance(), t), 0; got != want {
 		t.Errorf("Network instance has %d entry/entries, wanted: %d", got, want)
 	}
 
 	// clientA is primary client
-	injectEntry(ctx, t, args.clientA, *deviations.DefaultNetworkInstance)
+	injectEntry(ctx, t, args.clientA, deviations.For(args.dut).DefaultNetworkInstance())
 
 	// flush should fail, and preserve 3 entries.
-	if res, err := gribi.Flush(args.clientB, args.electionID.Decrement(), *deviations.DefaultNetworkInstance); err == nil {
+	if res, err := gribi.Flush(args.clientB, args.electionID.Decrement(), deviations.For(args.dut).DefaultNetworkInstance()); err == nil {
 		t.Errorf("Flush should return an error, got response: %v", res)
 	}
 
-	if got, want := checkNIHasNEntries(ctx, args.clientB, *deviations.DefaultNetworkInstance, t), 3; got != want {
+	if got, want := checkNIHasNEntries(ctx, args.clientB, deviations.For(args.dut).DefaultNetworkInstance(), t), 3; got != want {
 		t.Errorf("Network instance has %d entry/entries, wanted: %d", got, want)
 	}
 
@@ -203,11 +203,11 @@ func testFlushWithDefaultNetworkInstance(ctx context.Context, t *testing.T, args
 	eID := gribi.BecomeLeader(t, args.clientB)
 
 	// Flush should be succeed and 0 entry left.
-	if _, err := gribi.Flush(args.clientB, eID, *deviations.DefaultNetworkInstance); err != nil {
+	if _, err := gribi.Flush(args.clientB, eID, deviations.For(args.dut).DefaultNetworkInstance()); err != nil {
 		t.Fatalf("Unexpected error from flush, got: %v", err)
 	}
 
-	if got, want := checkNIHasNEntries(ctx, args.clientB, *deviations.DefaultNetworkInstance, t), 0; got != want {
+	if got, want := checkNIHasNEntries(ctx, args.clientB, deviations.For(args.dut).DefaultNetworkInstance(), t), 0; got != want {
 		t.Errorf("Network instance has %d entry/entries, wanted: %d", got, want)
 	}
 }
@@ -220,15 +220,15 @@ func configureDUT(t *testing.T, dut *ondatra.DUTDevice) {
 	p1 := dut.Port(t, "port1")
 	p2 := dut.Port(t, "port2")
 
-	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name()))
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, p1.Name(), *deviations.DefaultNetworkInstance, 0)
+	gnmi.Replace(t, dut, d.Interface(p1.Name()).Config(), dutPort1.NewOCInterface(p1.Name(), dut))
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, p1.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
-	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name()))
-	if *deviations.ExplicitInterfaceInDefaultVRF {
-		fptest.AssignToNetworkInstance(t, dut, p2.Name(), *deviations.DefaultNetworkInstance, 0)
+	gnmi.Replace(t, dut, d.Interface(p2.Name()).Config(), dutPort2.NewOCInterface(p2.Name(), dut))
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
+		fptest.AssignToNetworkInstance(t, dut, p2.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 	}
-	if *deviations.ExplicitPortSpeed {
+	if deviations.For(dut).ExplicitPortSpeed() {
 		fptest.SetPortSpeed(t, p1)
 		fptest.SetPortSpeed(t, p2)
 	}
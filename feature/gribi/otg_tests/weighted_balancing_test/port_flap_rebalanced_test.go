@@ -129,7 +129,7 @@ func TestPortFlap(t *testing.T) {
 		t.Errorf("Cannot flush: %v", err)
 	}
 
-	ents, wants := buildNextHops(t, nexthops, 1)
+	ents, wants := buildNextHops(t, dut, nexthops, 1)
 
 	c.Modify().AddEntry(t, ents...)
 	if err := awaitTimeout(ctx, c, t, time.Minute); err != nil {
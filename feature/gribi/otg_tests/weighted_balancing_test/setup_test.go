@@ -37,7 +37,6 @@ import (
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/gnmi"
 	"github.com/openconfig/ondatra/gnmi/oc"
-	"github.com/openconfig/ygnmi/ygnmi"
 	"github.com/openconfig/ygot/ygot"
 )
 
@@ -153,14 +152,14 @@ type nextHop struct {
 // dutInterface builds a DUT interface ygot struct for a given port
 // according to portsIPv4.  Returns nil if the port has no IP address
 // mapping.
-func dutInterface(p *ondatra.Port) *oc.Interface {
+func dutInterface(dut *ondatra.DUTDevice, p *ondatra.Port) *oc.Interface {
 	id := fmt.Sprintf("%s:%s", p.Device().ID(), p.ID())
 	i := &oc.Interface{
 		Name:        ygot.String(p.Name()),
 		Description: ygot.String(p.String()),
 		Type:        oc.IETFInterfaces_InterfaceType_ethernetCsmacd,
 	}
-	if *deviations.InterfaceEnabled {
+	if deviations.For(dut).InterfaceEnabled() {
 		i.Enabled = ygot.Bool(true)
 	}
 
@@ -171,7 +170,7 @@ func dutInterface(p *ondatra.Port) *oc.Interface {
 
 	s := i.GetOrCreateSubinterface(0)
 	s4 := s.GetOrCreateIpv4()
-	if *deviations.InterfaceEnabled && !*deviations.IPv4MissingEnabled {
+	if deviations.For(dut).InterfaceEnabled() && !deviations.For(dut).IPv4MissingEnabled() {
 		s4.Enabled = ygot.Bool(true)
 	}
 
@@ -193,22 +192,22 @@ func configureDUT(t testing.TB, dut *ondatra.DUTDevice) {
 	}
 	static.GetOrCreateNextHop("AUTO_drop_2").
 		NextHop = oc.LocalRouting_LOCAL_DEFINED_NEXT_HOP_DROP
-	staticp := dc.NetworkInstance(*deviations.DefaultNetworkInstance).
-		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, *deviations.StaticProtocolName).
+	staticp := dc.NetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
+		Protocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, deviations.For(dut).StaticProtocolName()).
 		Static(discardCIDR)
 	fptest.LogQuery(t, "discard route", staticp.Config(), static)
 	gnmi.Replace(t, dut, staticp.Config(), static)
 
 	for _, dp := range dut.Ports() {
-		if i := dutInterface(dp); i != nil {
+		if i := dutInterface(dut, dp); i != nil {
 			gnmi.Replace(t, dut, dc.Interface(dp.Name()).Config(), i)
 		} else {
 			t.Fatalf("No address found for port %v", dp)
 		}
 	}
-	if *deviations.ExplicitInterfaceInDefaultVRF {
+	if deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
 		for _, dp := range dut.Ports() {
-			fptest.AssignToNetworkInstance(t, dut, dp.Name(), *deviations.DefaultNetworkInstance, 0)
+			fptest.AssignToNetworkInstance(t, dut, dp.Name(), deviations.For(dut).DefaultNetworkInstance(), 0)
 		}
 	}
 }
@@ -255,8 +254,8 @@ func awaitTimeout(ctx context.Context, c *fluent.GRIBIClient, t testing.TB, time
 // buildNextHops converts the nextHop specification to gRIBI entries
 // and wanted OpResult.  The entries are part of the Modify request,
 // and the Modify response is verified against the wants.
-func buildNextHops(t testing.TB, nexthops []nextHop, scale uint64) (ents []fluent.GRIBIEntry, wants []*client.OpResult) {
-	nhgent := fluent.NextHopGroupEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+func buildNextHops(t testing.TB, dut *ondatra.DUTDevice, nexthops []nextHop, scale uint64) (ents []fluent.GRIBIEntry, wants []*client.OpResult) {
+	nhgent := fluent.NextHopGroupEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithID(nhgIndex)
 	nhgwant := fluent.OperationResult().
 		WithOperationID(uint64(len(nexthops) + 1)).
@@ -271,7 +270,7 @@ func buildNextHops(t testing.TB, nexthops []nextHop, scale uint64) (ents []fluen
 		t.Logf("Installing gRIBI next hop entry %d to %s (%s) of weight %d",
 			index, nhip, nh.Port, nh.Weight*scale)
 
-		ent := fluent.NextHopEntry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+		ent := fluent.NextHopEntry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 			WithIndex(index).WithIPAddress(nhip)
 		ents = append(ents, ent)
 
@@ -286,7 +285,7 @@ func buildNextHops(t testing.TB, nexthops []nextHop, scale uint64) (ents []fluen
 		wants = append(wants, want)
 	}
 
-	ipv4ent := fluent.IPv4Entry().WithNetworkInstance(*deviations.DefaultNetworkInstance).
+	ipv4ent := fluent.IPv4Entry().WithNetworkInstance(deviations.For(dut).DefaultNetworkInstance()).
 		WithPrefix(ateDstNetCIDR).WithNextHopGroup(42)
 	ipv4want := fluent.OperationResult().
 		WithOperationID(uint64(len(nexthops) + 2)).
@@ -319,7 +318,14 @@ func generateTraffic(t *testing.T, ate *ondatra.ATEDevice, config gosnappi.Confi
 	re, _ := regexp.Compile(".+:([a-zA-Z0-9]+)")
 	dutString := "dut:" + re.FindStringSubmatch(ateSrcPort)[1]
 	gwIp := portsIPv4[dutString]
-	waitOTGARPEntry(t, time.Minute)
+	// Only the source port sends traffic, and the other ports may be down.
+	src := gosnappi.NewConfig()
+	for _, d := range config.Devices().Items() {
+		if d.Name() == ateSrcPort {
+			src.Devices().Append(d)
+		}
+	}
+	otgutils.WaitForARP(t, ate.OTG(), src, time.Minute)
 	dstMac := gnmi.Get(t, ate.OTG(), gnmi.OTG().Interface(ateSrcPort+".Eth").Ipv4Neighbor(gwIp).LinkLayerAddress().State())
 	config.Flows().Clear().Items()
 	flow := config.Flows().Add().SetName("flow")
@@ -430,7 +436,7 @@ func portWants(nexthops []nextHop, atePorts []*ondatra.Port) []float64 {
 
 func debugGRIBI(t testing.TB, dut *ondatra.DUTDevice) {
 	// Debugging through OpenConfig.
-	aftsPath := gnmi.OC().NetworkInstance(*deviations.DefaultNetworkInstance).Afts()
+	aftsPath := gnmi.OC().NetworkInstance(deviations.For(dut).DefaultNetworkInstance()).Afts()
 	if q, present := gnmi.Lookup(t, dut, aftsPath.State()).Val(); present {
 		fptest.LogQuery(t, "Afts", aftsPath.State(), q)
 	} else {
@@ -454,12 +460,3 @@ func incrementMAC(mac string, i int) (string, error) {
 	newMac := net.HardwareAddr(buf.Bytes()[2:8])
 	return newMac.String(), nil
 }
-
-// waitOTGArpEntry ensures that ARP entries are present on the tx otg interface and traffic could be started
-func waitOTGARPEntry(t *testing.T, timeout time.Duration) {
-	t.Helper()
-	ate := ondatra.ATE(t, "ate")
-	gnmi.WatchAll(t, ate.OTG(), gnmi.OTG().Interface(ateSrcPort+".Eth").Ipv4NeighborAny().LinkLayerAddress().State(), timeout, func(val *ygnmi.Value[string]) bool {
-		return val.IsPresent()
-	}).Await(t)
-}
@@ -104,9 +104,9 @@ type testCase struct {
 
 // configInterfaceDUT configures an oc Interface with the desired MTU.
 func (tc *testCase) configInterfaceDUT(i *oc.Interface, dp *ondatra.Port, a *attrs.Attributes) {
-	a.ConfigOCInterface(i)
+	a.ConfigOCInterface(i, tc.dut)
 
-	if !*deviations.OmitL2MTU {
+	if !deviations.For(tc.dut).OmitL2MTU() {
 		i.Mtu = ygot.Uint16(tc.mtu + 14)
 	}
 
@@ -219,7 +219,7 @@ func (tc *testCase) verifyInterfaceDUT(
 
 	disp := dip.Subinterface(0)
 
-	if !*deviations.IPNeighborMissing {
+	if !deviations.For(tc.dut).IPNeighborMissing() {
 		// IPv4 neighbor discovered by ARP.
 		dis4np := disp.Ipv4().Neighbor(atea.IPv4)
 		neigbour := gnmi.Get(t, tc.dut, dis4np.State())
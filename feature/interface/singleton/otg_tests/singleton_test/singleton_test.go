@@ -131,7 +131,7 @@ var portSpeed = map[ondatra.Speed]oc.E_IfEthernet_ETHERNET_SPEED{
 
 // configInterfaceDUT configures an oc Interface with the desired MTU.
 func (tc *testCase) configInterfaceDUT(i *oc.Interface, dp *ondatra.Port, a *attrs.Attributes) {
-	a.ConfigOCInterface(i)
+	a.ConfigOCInterface(i, tc.dut)
 
 	e := i.GetOrCreateEthernet()
 	if tc.auto == autoNegotiation || tc.auto == autoNegotiationWithDuplexSpeed {
@@ -146,7 +146,7 @@ func (tc *testCase) configInterfaceDUT(i *oc.Interface, dp *ondatra.Port, a *att
 		}
 	}
 
-	if !*deviations.OmitL2MTU {
+	if !deviations.For(tc.dut).OmitL2MTU() {
 		i.Mtu = ygot.Uint16(tc.mtu + 14)
 	}
 
@@ -36,19 +36,19 @@ func TestMain(m *testing.M) {
 	fptest.RunTests(m)
 }
 
-func assignPort(t *testing.T, d *oc.Root, intf, niName string, a *attrs.Attributes) {
+func assignPort(t *testing.T, dut *ondatra.DUTDevice, d *oc.Root, intf, niName string, a *attrs.Attributes) {
 	t.Helper()
 	ni := d.GetOrCreateNetworkInstance(niName)
-	if niName != *deviations.DefaultNetworkInstance {
+	if niName != deviations.For(dut).DefaultNetworkInstance() {
 		ni.Type = oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF
 	}
-	if niName != *deviations.DefaultNetworkInstance || *deviations.ExplicitInterfaceInDefaultVRF {
+	if niName != deviations.For(dut).DefaultNetworkInstance() || deviations.For(dut).ExplicitInterfaceInDefaultVRF() {
 		niIntf := ni.GetOrCreateInterface(intf)
 		niIntf.Interface = ygot.String(intf)
 		niIntf.Subinterface = ygot.Uint32(0)
 	}
 
-	ocInt := a.ConfigOCInterface(&oc.Interface{})
+	ocInt := a.ConfigOCInterface(&oc.Interface{}, dut)
 	ocInt.Name = ygot.String(intf)
 
 	if err := d.AppendInterface(ocInt); err != nil {
@@ -121,28 +121,28 @@ func TestDefaultAddressFamilies(t *testing.T) {
 
 	ate.OTG().PushConfig(t, top)
 
+	dut := ondatra.DUT(t, "dut")
 	cases := []struct {
 		desc   string
 		niName string
 	}{
 		{
 			desc:   "Default network instance",
-			niName: *deviations.DefaultNetworkInstance,
+			niName: deviations.For(dut).DefaultNetworkInstance(),
 		},
 		{
 			desc:   "Non default network instance",
 			niName: "xyz",
 		},
 	}
-	dut := ondatra.DUT(t, "dut")
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			// Reset DUT config.
 			dut.Config().New().WithText("").Push(t)
 			d := &oc.Root{}
 			// Assign two ports into the network instance.
-			assignPort(t, d, dut.Port(t, "port1").Name(), tc.niName, dutPort1)
-			assignPort(t, d, dut.Port(t, "port2").Name(), tc.niName, dutPort2)
+			assignPort(t, dut, d, dut.Port(t, "port1").Name(), tc.niName, dutPort1)
+			assignPort(t, dut, d, dut.Port(t, "port2").Name(), tc.niName, dutPort2)
 
 			fptest.LogQuery(t, "test configuration", gnmi.OC().Config(), d)
 			gnmi.Update(t, dut, gnmi.OC().Config(), d)
@@ -417,6 +417,7 @@ func findMatchedComponents(t *testing.T, dut *ondatra.DUTDevice, r *regexp.Regex
 func ValidateComponentState(t *testing.T, dut *ondatra.DUTDevice, cards []string, p properties) {
 	t.Helper()
 
+	c := fptest.NewCollector(t)
 	swVersionFound := false
 	for _, card := range cards {
 		t.Logf("Validate card %s", card)
@@ -434,115 +435,141 @@ func ValidateComponentState(t *testing.T, dut *ondatra.DUTDevice, cards []string
 		}
 
 		if p.descriptionValidation {
-			description := gnmi.Get(t, dut, component.Description().State())
-			t.Logf("Hardware card %s Description: %s", card, description)
-			if description == "" {
-				t.Errorf("component.Description().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" description", func(t testing.TB) {
+				description := gnmi.Get(t, dut, component.Description().State())
+				t.Logf("Hardware card %s Description: %s", card, description)
+				if description == "" {
+					t.Errorf("component.Description().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.idValidation {
-			id := gnmi.Get(t, dut, component.Id().State())
-			t.Logf("Hardware card %s Id: %s", card, id)
-			if id == "" {
-				t.Errorf("component.Id().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" id", func(t testing.TB) {
+				id := gnmi.Get(t, dut, component.Id().State())
+				t.Logf("Hardware card %s Id: %s", card, id)
+				if id == "" {
+					t.Errorf("component.Id().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.nameValidation {
-			name := gnmi.Get(t, dut, component.Name().State())
-			t.Logf("Hardware card %s Name: %s", card, name)
-			if name == "" {
-				t.Errorf("component.Name().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" name", func(t testing.TB) {
+				name := gnmi.Get(t, dut, component.Name().State())
+				t.Logf("Hardware card %s Name: %s", card, name)
+				if name == "" {
+					t.Errorf("component.Name().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.partNoValidation {
-			partNo := gnmi.Get(t, dut, component.PartNo().State())
-			t.Logf("Hardware card %s PartNo: %s", card, partNo)
-			if partNo == "" {
-				t.Errorf("component.PartNo().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" part-no", func(t testing.TB) {
+				partNo := gnmi.Get(t, dut, component.PartNo().State())
+				t.Logf("Hardware card %s PartNo: %s", card, partNo)
+				if partNo == "" {
+					t.Errorf("component.PartNo().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.serialNoValidation {
-			serialNo := gnmi.Get(t, dut, component.SerialNo().State())
-			t.Logf("Hardware card %s serialNo: %s", card, serialNo)
-			if serialNo == "" {
-				t.Errorf("component.SerialNo().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" serial-no", func(t testing.TB) {
+				serialNo := gnmi.Get(t, dut, component.SerialNo().State())
+				t.Logf("Hardware card %s serialNo: %s", card, serialNo)
+				if serialNo == "" {
+					t.Errorf("component.SerialNo().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.mfgNameValidation {
-			mfgName := gnmi.Get(t, dut, component.MfgName().State())
-			t.Logf("Hardware card %s mfgName: %s", card, mfgName)
-			if mfgName == "" {
-				t.Errorf("Get mfgName for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" mfg-name", func(t testing.TB) {
+				mfgName := gnmi.Get(t, dut, component.MfgName().State())
+				t.Logf("Hardware card %s mfgName: %s", card, mfgName)
+				if mfgName == "" {
+					t.Errorf("Get mfgName for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.mfgDateValidation {
-			mfgDate := gnmi.Get(t, dut, component.MfgDate().State())
-			t.Logf("Hardware card %s mfgDate: %s", card, mfgDate)
-			if mfgDate == "" {
-				t.Errorf("component.MfgName().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" mfg-date", func(t testing.TB) {
+				mfgDate := gnmi.Get(t, dut, component.MfgDate().State())
+				t.Logf("Hardware card %s mfgDate: %s", card, mfgDate)
+				if mfgDate == "" {
+					t.Errorf("component.MfgName().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.swVerValidation {
-			softwareVersion := ""
-			// Only a subset of cards are expected to report Software Version.
-			sw, present := gnmi.Lookup(t, dut, component.SoftwareVersion().State()).Val()
-			if present {
-				t.Logf("Hardware card %s SoftwareVersion: %s", card, sw)
-				swVersionFound = true
-				if softwareVersion == "" {
-					t.Errorf("component.SoftwareVersion().Get(t) for %q): got empty string, want non-empty string", card)
+			c.Run(card+" software-version", func(t testing.TB) {
+				softwareVersion := ""
+				// Only a subset of cards are expected to report Software Version.
+				sw, present := gnmi.Lookup(t, dut, component.SoftwareVersion().State()).Val()
+				if present {
+					t.Logf("Hardware card %s SoftwareVersion: %s", card, sw)
+					swVersionFound = true
+					if softwareVersion == "" {
+						t.Errorf("component.SoftwareVersion().Get(t) for %q): got empty string, want non-empty string", card)
+					}
+				} else {
+					t.Logf("component.SoftwareVersion().Lookup(t) for %q): got no value.", card)
 				}
-			} else {
-				t.Logf("component.SoftwareVersion().Lookup(t) for %q): got no value.", card)
-			}
+			})
 		}
 
 		if p.hwVerValidation {
-			hardwareVersion := gnmi.Get(t, dut, component.HardwareVersion().State())
-			t.Logf("Hardware card %s hardwareVersion: %s", card, hardwareVersion)
-			if hardwareVersion == "" {
-				t.Errorf("component.HardwareVersion().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" hardware-version", func(t testing.TB) {
+				hardwareVersion := gnmi.Get(t, dut, component.HardwareVersion().State())
+				t.Logf("Hardware card %s hardwareVersion: %s", card, hardwareVersion)
+				if hardwareVersion == "" {
+					t.Errorf("component.HardwareVersion().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.fwVerValidation {
-			firmwareVersion := gnmi.Get(t, dut, component.FirmwareVersion().State())
-			t.Logf("Hardware card %s FirmwareVersion: %s", card, firmwareVersion)
-			if firmwareVersion == "" {
-				t.Errorf("component.FirmwareVersion().Get(t) for %q): got empty string, want non-empty string", card)
-			}
+			c.Run(card+" firmware-version", func(t testing.TB) {
+				firmwareVersion := gnmi.Get(t, dut, component.FirmwareVersion().State())
+				t.Logf("Hardware card %s FirmwareVersion: %s", card, firmwareVersion)
+				if firmwareVersion == "" {
+					t.Errorf("component.FirmwareVersion().Get(t) for %q): got empty string, want non-empty string", card)
+				}
+			})
 		}
 
 		if p.operStatus != "" {
-			operStatus := gnmi.Get(t, dut, component.OperStatus().State()).String()
-			t.Logf("Hardware card %s OperStatus: %s", card, operStatus)
-			if operStatus != activeStatus {
-				t.Errorf("component.OperStatus().Get(t) for %q): got %v, want %v", card, operStatus, p.operStatus)
-			}
+			c.Run(card+" oper-status", func(t testing.TB) {
+				operStatus := gnmi.Get(t, dut, component.OperStatus().State()).String()
+				t.Logf("Hardware card %s OperStatus: %s", card, operStatus)
+				if operStatus != activeStatus {
+					t.Errorf("component.OperStatus().Get(t) for %q): got %v, want %v", card, operStatus, p.operStatus)
+				}
+			})
 		}
 
 		if p.parent != "" {
-			parent := gnmi.Get(t, dut, component.Parent().State())
-			t.Logf("Hardware card %s parent: %s", card, parent)
-			if parent != p.parent {
-				t.Errorf("component.Parent().Get(t) for %q): got %v, want %v", card, parent, p.parent)
-			}
+			c.Run(card+" parent", func(t testing.TB) {
+				parent := gnmi.Get(t, dut, component.Parent().State())
+				t.Logf("Hardware card %s parent: %s", card, parent)
+				if parent != p.parent {
+					t.Errorf("component.Parent().Get(t) for %q): got %v, want %v", card, parent, p.parent)
+				}
+			})
 		}
 
 		if p.pType != "" {
-			ptype := gnmi.Get(t, dut, component.Type().State())
-			t.Logf("Hardware card %s type: %v", card, ptype)
+			c.Run(card+" type", func(t testing.TB) {
+				ptype := gnmi.Get(t, dut, component.Type().State())
+				t.Logf("Hardware card %s type: %v", card, ptype)
 
-			if fmt.Sprintf("%v", ptype) != p.pType {
-				t.Errorf("component.Type().Get(t) for %q): got %v, want %v", card, ptype, p.pType)
-			}
+				if fmt.Sprintf("%v", ptype) != p.pType {
+					t.Errorf("component.Type().Get(t) for %q): got %v, want %v", card, ptype, p.pType)
+				}
+			})
 		}
 	}
 	if p.swVerValidation && !swVersionFound {
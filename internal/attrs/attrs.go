@@ -56,16 +56,18 @@ func (a *Attributes) IPv6CIDR() string {
 	return fmt.Sprintf("%s/%d", a.IPv6, a.IPv6Len)
 }
 
-// ConfigOCInterface configures an OpenConfig interface with these attributes.
-func (a *Attributes) ConfigOCInterface(intf *oc.Interface) *oc.Interface {
+// ConfigOCInterface configures an OpenConfig interface with these attributes,
+// applying the deviations of the DUT.
+func (a *Attributes) ConfigOCInterface(intf *oc.Interface, dut *ondatra.DUTDevice) *oc.Interface {
+	dev := deviations.For(dut)
 	if a.Desc != "" {
 		intf.Description = ygot.String(a.Desc)
 	}
 	intf.Type = oc.IETFInterfaces_InterfaceType_ethernetCsmacd
-	if *deviations.InterfaceEnabled {
+	if dev.InterfaceEnabled() {
 		intf.Enabled = ygot.Bool(true)
 	}
-	if a.MTU > 0 && !*deviations.OmitL2MTU {
+	if a.MTU > 0 && !dev.OmitL2MTU() {
 		intf.Mtu = ygot.Uint16(a.MTU + 14)
 	}
 	e := intf.GetOrCreateEthernet()
@@ -76,7 +78,7 @@ func (a *Attributes) ConfigOCInterface(intf *oc.Interface) *oc.Interface {
 	s := intf.GetOrCreateSubinterface(0)
 	if a.IPv4 != "" {
 		s4 := s.GetOrCreateIpv4()
-		if *deviations.InterfaceEnabled && !*deviations.IPv4MissingEnabled {
+		if dev.InterfaceEnabled() && !dev.IPv4MissingEnabled() {
 			s4.Enabled = ygot.Bool(true)
 		}
 		if a.MTU > 0 {
@@ -93,7 +95,7 @@ func (a *Attributes) ConfigOCInterface(intf *oc.Interface) *oc.Interface {
 		if a.MTU > 0 {
 			s6.Mtu = ygot.Uint32(uint32(a.MTU))
 		}
-		if *deviations.InterfaceEnabled {
+		if dev.InterfaceEnabled() {
 			s6.Enabled = ygot.Bool(true)
 		}
 		a6 := s6.GetOrCreateAddress(a.IPv6)
@@ -104,9 +106,10 @@ func (a *Attributes) ConfigOCInterface(intf *oc.Interface) *oc.Interface {
 	return intf
 }
 
-// NewOCInterface returns a new *oc.Interface configured with these attributes.
-func (a *Attributes) NewOCInterface(name string) *oc.Interface {
-	return a.ConfigOCInterface(&oc.Interface{Name: ygot.String(name)})
+// NewOCInterface returns a new *oc.Interface configured with these attributes,
+// applying the deviations of the DUT.
+func (a *Attributes) NewOCInterface(name string, dut *ondatra.DUTDevice) *oc.Interface {
+	return a.ConfigOCInterface(&oc.Interface{Name: ygot.String(name)}, dut)
 }
 
 // AddToATE adds a new interface to an ATETopology with these attributes.
@@ -69,9 +69,10 @@ func FindMatchingStrings(components []string, r *regexp.Regexp) []string {
 	return s
 }
 
-// GetSubcomponentPath creates a gNMI path based on the componnent name.
-func GetSubcomponentPath(name string) *tpb.Path {
-	if *deviations.GNOISubcomponentPath {
+// GetSubcomponentPath creates a gNMI path based on the componnent name, applying the
+// deviations of the DUT.
+func GetSubcomponentPath(name string, dut *ondatra.DUTDevice) *tpb.Path {
+	if deviations.For(dut).GNOISubcomponentPath() {
 		return &tpb.Path{
 			Elem: []*tpb.PathElem{{Name: name}},
 		}
@@ -18,6 +18,7 @@ package confirm
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"testing"
 
 	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
@@ -53,8 +54,8 @@ func PathLabel(pth *gnmipb.Path) string {
 
 // getSingleValue is ytypes.GetNode, except it returns the Data of the single
 // node found, or an error if the number of matching nodes is not exactly 1.
-func getSingleValue(schema *yang.Entry, root ygot.ValidatedGoStruct, pth *gnmipb.Path) (interface{}, error) {
-	vals, err := ytypes.GetNode(schema, root, pth)
+func getSingleValue(schema *yang.Entry, root ygot.ValidatedGoStruct, pth *gnmipb.Path, opts ...ytypes.GetNodeOpt) (interface{}, error) {
+	vals, err := ytypes.GetNode(schema, root, pth, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -84,13 +85,17 @@ func Readable(v interface{}) string {
 
 // ExtractChanges turns a Notification into a collection of Change objects.
 func ExtractChanges(diff *gnmipb.Notification, want, got ygot.ValidatedGoStruct) ([]*Change, error) {
+	return extractChanges(diff, want, got)
+}
+
+func extractChanges(diff *gnmipb.Notification, want, got ygot.ValidatedGoStruct, opts ...ytypes.GetNodeOpt) ([]*Change, error) {
 	schema, err := getSchema(want)
 	if err != nil {
 		return nil, fmt.Errorf("schema lookup failure: %v", err)
 	}
 	var changes []*Change
 	for _, pth := range diff.GetDelete() {
-		wantVal, err := getSingleValue(schema, want, pth)
+		wantVal, err := getSingleValue(schema, want, pth, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("faild to parse expected value at path %v: %v", pth, err)
 		}
@@ -98,11 +103,11 @@ func ExtractChanges(diff *gnmipb.Notification, want, got ygot.ValidatedGoStruct)
 	}
 	for _, upd := range diff.GetUpdate() {
 		pth := upd.GetPath()
-		gotVal, err := getSingleValue(schema, got, pth)
+		gotVal, err := getSingleValue(schema, got, pth, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("faild to parse received value at path %v: %v", pth, err)
 		}
-		wantVal, err := getSingleValue(schema, want, pth)
+		wantVal, err := getSingleValue(schema, want, pth, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("faild to parse expected value at path %v: %v", pth, err)
 		}
@@ -111,18 +116,57 @@ func ExtractChanges(diff *gnmipb.Notification, want, got ygot.ValidatedGoStruct)
 	return changes, nil
 }
 
+// Diff returns the differences between the set values in want and the values in got, except for
+// the ones accepted by the options.
+func Diff(want, got ygot.ValidatedGoStruct, opts ...Option) ([]*Change, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	diffOpts := []ygot.DiffOpt{&ygot.IgnoreAdditions{}}
+	var getOpts []ytypes.GetNodeOpt
+	if o.config {
+		diffOpts = append(diffOpts, &ygot.DiffPathOpt{PreferShadowPath: true})
+		getOpts = append(getOpts, &ytypes.PreferShadowPath{})
+	}
+	diff, err := ygot.Diff(want, got, diffOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("ygot.Diff failure: %w", err)
+	}
+	changes, err := extractChanges(diff, want, got, getOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare states: %w", err)
+	}
+	schema, err := getSchema(want)
+	if err != nil {
+		return nil, fmt.Errorf("schema lookup failure: %w", err)
+	}
+	var reported []*Change
+	for _, change := range changes {
+		if !o.ignored(schema, change) {
+			reported = append(reported, change)
+		}
+	}
+	sort.Slice(reported, func(i, j int) bool {
+		return PathLabel(reported[i].Path) < PathLabel(reported[j].Path)
+	})
+	return reported, nil
+}
+
 // State checks that every set value in want is present in got. Extra fields in got will be ignored
-// (typically the state contains many more keys than just the ones we're setting).
+// (typically the state contains many more keys than just the ones we're setting). The options
+// relax the comparison, e.g.
+//
+//	confirm.State(t, want, got,
+//	  confirm.IgnorePaths("/state/counters"),
+//	  confirm.SchemaDefaults(),
+//	  confirm.TimeTolerance("/state/last-change", time.Minute),
+//	  confirm.LeafListsAsSets())
 //
 // DEPRECATED: experimental function
-func State(t testing.TB, want, got ygot.ValidatedGoStruct) {
+func State(t testing.TB, want, got ygot.ValidatedGoStruct, opts ...Option) {
 	t.Helper()
-	diff, err := ygot.Diff(want, got, &ygot.IgnoreAdditions{})
-	if err != nil {
-		t.Errorf("ygot.Diff failure: %v", err)
-		return
-	}
-	changes, err := ExtractChanges(diff, want, got)
+	changes, err := Diff(want, got, opts...)
 	if err != nil {
 		t.Errorf("Failed to compare states: %v", err)
 		return
@@ -0,0 +1,179 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestDiff(t *testing.T) {
+	want := &oc.Interface{
+		Name:       ygot.String("Ethernet1/1"),
+		Enabled:    ygot.Bool(true),
+		Mtu:        ygot.Uint16(1500),
+		LastChange: ygot.Uint64(uint64(time.Second)),
+	}
+	want.GetOrCreateCounters().InPkts = ygot.Uint64(100)
+	want.GetOrCreateSubinterface(0).GetOrCreateIpv4().GetOrCreateAddress("192.0.2.1").PrefixLength = ygot.Uint8(30)
+	got := &oc.Interface{
+		Name:       ygot.String("Ethernet1/1"),
+		Mtu:        ygot.Uint16(1514),
+		LastChange: ygot.Uint64(uint64(2 * time.Second)),
+	}
+	got.GetOrCreateCounters().InPkts = ygot.Uint64(150)
+	got.GetOrCreateSubinterface(0).GetOrCreateIpv4().GetOrCreateAddress("192.0.2.1").PrefixLength = ygot.Uint8(31)
+
+	cases := []struct {
+		desc string
+		opts []Option
+		want []string
+	}{{
+		desc: "no options",
+		want: []string{
+			"/state/counters/in-pkts",
+			"/state/enabled",
+			"/state/last-change",
+			"/state/mtu",
+			"/subinterfaces/subinterface[index=0]/ipv4/addresses/address[ip=192.0.2.1]/state/prefix-length",
+		},
+	}, {
+		desc: "ignore paths",
+		opts: []Option{IgnorePaths("/state/counters", "/subinterfaces/subinterface[index=*]/.../prefix-length")},
+		want: []string{"/state/enabled", "/state/last-change", "/state/mtu"},
+	}, {
+		desc: "ignore wildcard element",
+		opts: []Option{IgnorePaths("/*/mtu", "/.../address")},
+		want: []string{"/state/counters/in-pkts", "/state/enabled", "/state/last-change"},
+	}, {
+		desc: "schema defaults",
+		opts: []Option{SchemaDefaults()},
+		want: []string{
+			"/state/counters/in-pkts",
+			"/state/last-change",
+			"/state/mtu",
+			"/subinterfaces/subinterface[index=0]/ipv4/addresses/address[ip=192.0.2.1]/state/prefix-length",
+		},
+	}, {
+		desc: "tolerance",
+		opts: []Option{Tolerance("/state/counters", 50), Tolerance("/state/mtu", 10), TimeTolerance("/state/last-change", time.Second)},
+		want: []string{
+			"/state/enabled",
+			"/state/mtu",
+			"/subinterfaces/subinterface[index=0]/ipv4/addresses/address[ip=192.0.2.1]/state/prefix-length",
+		},
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			changes, err := Diff(want, got, c.opts...)
+			if err != nil {
+				t.Fatalf("Diff got error: %v", err)
+			}
+			var paths []string
+			for _, change := range changes {
+				paths = append(paths, PathLabel(change.Path))
+			}
+			if diff := cmp.Diff(c.want, paths); diff != "" {
+				t.Errorf("Diff paths diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiff_SchemaDefaultsMismatch(t *testing.T) {
+	want := &oc.Interface{Enabled: ygot.Bool(false)}
+	changes, err := Diff(want, &oc.Interface{}, SchemaDefaults())
+	if err != nil {
+		t.Fatalf("Diff got error: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("Diff got %d changes, want 1 since false is not the default of enabled", len(changes))
+	}
+}
+
+func TestDiff_LeafListsAsSets(t *testing.T) {
+	cases := []struct {
+		desc        string
+		want, got   []string
+		wantChanges int
+	}{
+		{desc: "reordered", want: []string{"a.example", "b.example"}, got: []string{"b.example", "a.example"}},
+		{desc: "repeated", want: []string{"a.example"}, got: []string{"a.example", "a.example"}},
+		{desc: "extra", want: []string{"a.example"}, got: []string{"a.example", "b.example"}, wantChanges: 1},
+		{desc: "missing", want: []string{"a.example", "b.example"}, got: []string{"a.example"}, wantChanges: 1},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			want, got := &oc.System{}, &oc.System{}
+			want.GetOrCreateDns().Search = c.want
+			got.GetOrCreateDns().Search = c.got
+			changes, err := Diff(want, got, LeafListsAsSets())
+			if err != nil {
+				t.Fatalf("Diff got error: %v", err)
+			}
+			if len(changes) != c.wantChanges {
+				t.Errorf("Diff got %d changes, want %d", len(changes), c.wantChanges)
+			}
+		})
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	a, b := "2022-10-01T10:00:00Z", "2022-10-01T10:00:30Z"
+	tol := tolerance{delta: float64(time.Minute), time: true}
+	if !withinTolerance(&a, &b, tol) {
+		t.Errorf("withinTolerance(%q, %q, 1m) got false, want true", a, b)
+	}
+	tol.delta = float64(10 * time.Second)
+	if withinTolerance(&a, &b, tol) {
+		t.Errorf("withinTolerance(%q, %q, 10s) got true, want false", a, b)
+	}
+}
+
+func TestWithinTolerance_Integers(t *testing.T) {
+	big := int64(1) << 60
+	cases := []struct {
+		desc      string
+		want, got interface{}
+		delta     float64
+		within    bool
+	}{
+		{"large int64 differ", big, big + 1, 0, false},
+		{"large int64 within", big, big + 1, 1, true},
+		{"uint64 differ", uint64(math.MaxUint64), uint64(math.MaxUint64 - 1), 0.5, false},
+		{"uint64 equal", uint64(math.MaxUint64), uint64(math.MaxUint64), 0, true},
+		{"int64 extremes", int64(math.MinInt64), int64(math.MaxInt64), math.MaxUint64, true},
+		{"signed and unsigned", int64(-1), uint64(1), 2, true},
+		{"float", 1.5, 1.0, 0.5, true},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := withinTolerance(c.want, c.got, tolerance{delta: c.delta}); got != c.within {
+				t.Errorf("withinTolerance(%v, %v, %v) got %v, want %v", c.want, c.got, c.delta, got, c.within)
+			}
+		})
+	}
+}
+
+func TestIgnorePaths_Error(t *testing.T) {
+	if _, err := Diff(&oc.Interface{}, &oc.Interface{}, IgnorePaths("/a[=c]")); err == nil {
+		t.Errorf("Diff with invalid glob got no error")
+	}
+}
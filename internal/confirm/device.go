@@ -0,0 +1,203 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/check"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// View is a set of views of a device.
+type View int
+
+const (
+	// StateView is the operational state of the device.
+	StateView View = 1 << iota
+	// ConfigView is the configuration of the device.
+	ConfigView
+	// BothViews is both the configuration and the operational state of the device.
+	BothViews = ConfigView | StateView
+)
+
+// Path is a ygnmi path struct which can be queried for both its config and its
+// state, e.g. gnmi.OC().Interface("Ethernet1").
+type Path[T any] interface {
+	Config() ygnmi.ConfigQuery[T]
+	State() ygnmi.SingletonQuery[T]
+}
+
+// leafDiff is a difference at one leaf.
+type leafDiff struct {
+	Leaf    string `json:"leaf"`
+	Want    string `json:"want"`
+	Got     string `json:"got,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// containerDiff is the differences at the leaves of one container.
+type containerDiff struct {
+	Container string      `json:"container"`
+	Leaves    []*leafDiff `json:"leaves"`
+}
+
+// viewReport is the outcome of comparing one view of a device.
+type viewReport struct {
+	Device     string           `json:"device"`
+	View       string           `json:"view"`
+	Path       string           `json:"path"`
+	NotPresent bool             `json:"not_present,omitempty"`
+	Containers []*containerDiff `json:"containers,omitempty"`
+}
+
+// newViewReport groups the changes by their container.  The changes must be
+// sorted by path, as returned by Diff.
+func newViewReport(device, view, path string, changes []*Change) *viewReport {
+	r := &viewReport{Device: device, View: view, Path: path}
+	for _, c := range changes {
+		elems := c.Path.GetElem()
+		container := "/"
+		if len(elems) > 1 {
+			container = PathLabel(&gnmipb.Path{Elem: elems[:len(elems)-1]})
+		}
+		if n := len(r.Containers); n == 0 || r.Containers[n-1].Container != container {
+			r.Containers = append(r.Containers, &containerDiff{Container: container})
+		}
+		d := &leafDiff{
+			Want:    Readable(c.Want),
+			Missing: c.Missing,
+		}
+		if len(elems) > 0 {
+			d.Leaf = elems[len(elems)-1].GetName()
+		}
+		if !c.Missing {
+			d.Got = Readable(c.Got)
+		}
+		cd := r.Containers[len(r.Containers)-1]
+		cd.Leaves = append(cd.Leaves, d)
+	}
+	return r
+}
+
+// String renders the report for the test log.
+func (r *viewReport) String() string {
+	var b strings.Builder
+	if r.NotPresent {
+		fmt.Fprintf(&b, "%s %s at %s: not present", r.Device, r.View, r.Path)
+		return b.String()
+	}
+	n := 0
+	for _, c := range r.Containers {
+		n += len(c.Leaves)
+	}
+	fmt.Fprintf(&b, "%s %s at %s: %d differences", r.Device, r.View, r.Path, n)
+	for _, c := range r.Containers {
+		fmt.Fprintf(&b, "\n  %s:", c.Container)
+		for _, l := range c.Leaves {
+			if l.Missing {
+				fmt.Fprintf(&b, "\n    %s: missing, want %s", l.Leaf, l.Want)
+			} else {
+				fmt.Fprintf(&b, "\n    %s: got %s, want %s", l.Leaf, l.Got, l.Want)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Device fetches the subtree at path from the device with a gNMI Get and checks
+// that every set value in want is present, like State.  The options select the
+// views to compare (StateView by default) and relax the comparison.  The config
+// view is compared at the config paths, e.g. "/config/mtu", which is what the
+// ignored paths and tolerances of the config view should match.
+//
+// The differences are reported grouped by container and also written as a
+// JSON artifact of the test, see fptest.Artifacts.
+//
+// DEPRECATED: experimental function
+func Device[T ygot.ValidatedGoStruct](t testing.TB, dut *ondatra.DUTDevice, path Path[T], want T, opts ...Option) {
+	t.Helper()
+	name := dut.Name()
+	o, err := newOptions(opts)
+	if err != nil {
+		t.Errorf("Failed to compare device %s: %v", name, err)
+		return
+	}
+	views := o.views
+	if views == 0 {
+		views = StateView
+	}
+	// The device is queried with its own client, leaving the gNMI options of
+	// the DUT as they are for the other queries of the test.
+	client, err := ygnmi.NewClient(dut.RawAPIs().GNMI().Default(t), ygnmi.WithTarget(name))
+	if err != nil {
+		t.Errorf("Failed to compare device %s: %v", name, err)
+		return
+	}
+	var reports []*viewReport
+	if views&ConfigView != 0 {
+		q := path.Config()
+		if r := compareView[T](t, client, name, "config", q, want, append(opts, configPaths())); r != nil {
+			reports = append(reports, r)
+		}
+	}
+	if views&StateView != 0 {
+		q := path.State()
+		if r := compareView(t, client, name, "state", q, want, opts); r != nil {
+			reports = append(reports, r)
+		}
+	}
+	if len(reports) == 0 {
+		return
+	}
+	for _, r := range reports {
+		t.Error(r.String())
+	}
+	if _, err := fptest.Artifacts(t).WriteJSON("confirm", reports); err != nil {
+		t.Errorf("Failed to write the differences: %v", err)
+	}
+}
+
+// compareView compares one view of the device and returns its report, or nil
+// if there is no difference.
+func compareView[T ygot.ValidatedGoStruct](t testing.TB, client *ygnmi.Client, name, view string, q ygnmi.SingletonQuery[T], want T, opts []Option) *viewReport {
+	t.Helper()
+	pathText := check.FormatPath(q.PathStruct())
+	v, err := ygnmi.Lookup(context.Background(), client, q, ygnmi.WithUseGet())
+	if err != nil {
+		t.Errorf("Failed to get %s %s at %s: %v", name, view, pathText, err)
+		return nil
+	}
+	got, ok := v.Val()
+	if !ok {
+		return &viewReport{Device: name, View: view, Path: pathText, NotPresent: true}
+	}
+	changes, err := Diff(want, got, opts...)
+	if err != nil {
+		t.Errorf("Failed to compare %s %s at %s: %v", name, view, pathText, err)
+		return nil
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return newViewReport(name, view, pathText, changes)
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func interfaces() (want, got *oc.Interface) {
+	want = &oc.Interface{
+		Name:        ygot.String("Ethernet1"),
+		Description: ygot.String("uplink"),
+		Mtu:         ygot.Uint16(1500),
+	}
+	want.GetOrCreateSubinterface(0).GetOrCreateIpv4().GetOrCreateAddress("192.0.2.1").PrefixLength = ygot.Uint8(30)
+	got = &oc.Interface{
+		Name: ygot.String("Ethernet1"),
+		Mtu:  ygot.Uint16(1514),
+	}
+	got.GetOrCreateSubinterface(0).GetOrCreateIpv4().GetOrCreateAddress("192.0.2.1").PrefixLength = ygot.Uint8(31)
+	return want, got
+}
+
+func TestViewReport(t *testing.T) {
+	want, got := interfaces()
+	changes, err := Diff(want, got)
+	if err != nil {
+		t.Fatalf("Diff got error: %v", err)
+	}
+	r := newViewReport("dut(device1)", "state", "/interfaces/interface[name=Ethernet1]", changes)
+
+	wantText := `dut(device1) state at /interfaces/interface[name=Ethernet1]: 3 differences
+  /state:
+    description: missing, want &uplink
+    mtu: got &1514, want &1500
+  /subinterfaces/subinterface[index=0]/ipv4/addresses/address[ip=192.0.2.1]/state:
+    prefix-length: got &31, want &30`
+	if diff := cmp.Diff(wantText, r.String()); diff != "" {
+		t.Errorf("String() diff (-want +got):\n%s", diff)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("json.Marshal got error: %v", err)
+	}
+	var gotJSON viewReport
+	if err := json.Unmarshal(b, &gotJSON); err != nil {
+		t.Fatalf("json.Unmarshal got error: %v", err)
+	}
+	if diff := cmp.Diff(r, &gotJSON); diff != "" {
+		t.Errorf("JSON round trip diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestViewReport_NotPresent(t *testing.T) {
+	r := &viewReport{Device: "dut(device1)", View: "config", Path: "/interfaces/interface[name=Ethernet1]", NotPresent: true}
+	if got, want := r.String(), "dut(device1) config at /interfaces/interface[name=Ethernet1]: not present"; got != want {
+		t.Errorf("String() got %q, want %q", got, want)
+	}
+}
+
+func TestDiff_ConfigPaths(t *testing.T) {
+	want, got := interfaces()
+	changes, err := Diff(want, got, configPaths(), IgnorePaths("/config/description"))
+	if err != nil {
+		t.Fatalf("Diff got error: %v", err)
+	}
+	var paths []string
+	for _, c := range changes {
+		paths = append(paths, PathLabel(c.Path))
+	}
+	wantPaths := []string{
+		"/config/mtu",
+		"/subinterfaces/subinterface[index=0]/ipv4/addresses/address[ip=192.0.2.1]/config/prefix-length",
+	}
+	if diff := cmp.Diff(wantPaths, paths); diff != "" {
+		t.Errorf("Diff paths diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestViews_Error(t *testing.T) {
+	for _, v := range []View{0, 4} {
+		if _, err := newOptions([]Option{Views(v)}); err == nil {
+			t.Errorf("Views(%d) got no error", v)
+		}
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confirm
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	gnmipb "github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Option customizes how State compares the wanted and the received values.
+type Option func(*options) error
+
+type tolerance struct {
+	glob  []*gnmipb.PathElem
+	delta float64
+	time  bool
+}
+
+type options struct {
+	ignore          [][]*gnmipb.PathElem
+	tolerances      []tolerance
+	schemaDefaults  bool
+	leafListsAsSets bool
+	views           View
+	config          bool // compare the config paths instead of the state paths
+}
+
+func newOptions(opts []Option) (*options, error) {
+	o := &options{}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// parseGlob parses a path glob relative to the compared struct, e.g.
+// "/state/counters" or "/subinterfaces/subinterface[index=*]/*/counters".
+func parseGlob(glob string) ([]*gnmipb.PathElem, error) {
+	p, err := ygot.StringToStructuredPath(glob)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path glob %q: %w", glob, err)
+	}
+	return p.GetElem(), nil
+}
+
+// IgnorePaths skips the differences at or below the paths matching any of the
+// globs. The globs are relative to the compared struct. An element name "*"
+// matches any one element, "..." matches any number of elements, and a key
+// value "*" matches any key value. Keys left out of the glob match any value.
+func IgnorePaths(globs ...string) Option {
+	return func(o *options) error {
+		for _, glob := range globs {
+			elems, err := parseGlob(glob)
+			if err != nil {
+				return err
+			}
+			o.ignore = append(o.ignore, elems)
+		}
+		return nil
+	}
+}
+
+// SchemaDefaults treats a leaf missing from the received value as equal to the
+// wanted value when the wanted value is the schema default of the leaf. This is
+// for devices that do not report defaulted values, see
+// deviation_missing_value_for_defaults.
+func SchemaDefaults() Option {
+	return func(o *options) error {
+		o.schemaDefaults = true
+		return nil
+	}
+}
+
+// Tolerance treats numeric values at or below the paths matching the glob as
+// equal when they differ by at most delta. See IgnorePaths for the glob syntax.
+func Tolerance(glob string, delta float64) Option {
+	return func(o *options) error {
+		elems, err := parseGlob(glob)
+		if err != nil {
+			return err
+		}
+		o.tolerances = append(o.tolerances, tolerance{glob: elems, delta: delta})
+		return nil
+	}
+}
+
+// TimeTolerance treats timestamps at or below the paths matching the glob as
+// equal when they differ by at most d. Timestamps are either nanoseconds since
+// the Unix epoch or RFC 3339 strings. See IgnorePaths for the glob syntax.
+func TimeTolerance(glob string, d time.Duration) Option {
+	return func(o *options) error {
+		elems, err := parseGlob(glob)
+		if err != nil {
+			return err
+		}
+		o.tolerances = append(o.tolerances, tolerance{glob: elems, delta: float64(d), time: true})
+		return nil
+	}
+}
+
+// LeafListsAsSets compares leaf-lists regardless of the order and the
+// repetition of their elements.
+func LeafListsAsSets() Option {
+	return func(o *options) error {
+		o.leafListsAsSets = true
+		return nil
+	}
+}
+
+// Views selects the views of the device that Device compares. It defaults to
+// StateView and has no effect on State and Diff.
+func Views(v View) Option {
+	return func(o *options) error {
+		if v&^BothViews != 0 || v == 0 {
+			return fmt.Errorf("invalid view %d", v)
+		}
+		o.views = v
+		return nil
+	}
+}
+
+// configPaths makes Diff compare and report the config paths of the structs,
+// which is how Device compares the config view.
+func configPaths() Option {
+	return func(o *options) error {
+		o.config = true
+		return nil
+	}
+}
+
+// matchElem reports whether a path element matches a glob element.
+func matchElem(glob, elem *gnmipb.PathElem) bool {
+	if glob.GetName() != "*" && glob.GetName() != elem.GetName() {
+		return false
+	}
+	for k, v := range glob.GetKey() {
+		if got, ok := elem.GetKey()[k]; !ok || (v != "*" && v != got) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPrefix reports whether the glob matches the path or any of its ancestors.
+func matchPrefix(glob, elems []*gnmipb.PathElem) bool {
+	if len(glob) == 0 {
+		return true
+	}
+	if glob[0].GetName() == "..." {
+		for i := 0; i <= len(elems); i++ {
+			if matchPrefix(glob[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 || !matchElem(glob[0], elems[0]) {
+		return false
+	}
+	return matchPrefix(glob[1:], elems[1:])
+}
+
+// ignored reports whether the change should not be reported.
+func (o *options) ignored(schema *yang.Entry, c *Change) bool {
+	elems := c.Path.GetElem()
+	for _, glob := range o.ignore {
+		if matchPrefix(glob, elems) {
+			return true
+		}
+	}
+	if c.Missing {
+		return o.schemaDefaults && isDefault(schema, elems, c.Want)
+	}
+	for _, tol := range o.tolerances {
+		if matchPrefix(tol.glob, elems) && withinTolerance(c.Want, c.Got, tol) {
+			return true
+		}
+	}
+	return o.leafListsAsSets && sameSet(c.Want, c.Got)
+}
+
+// leafSchema walks the schema down the path elements.
+func leafSchema(schema *yang.Entry, elems []*gnmipb.PathElem) *yang.Entry {
+	for _, e := range elems {
+		if schema == nil {
+			return nil
+		}
+		schema = schema.Dir[e.GetName()]
+	}
+	return schema
+}
+
+// isDefault reports whether v is the schema default of the leaf at the path.
+func isDefault(schema *yang.Entry, elems []*gnmipb.PathElem, v interface{}) bool {
+	leaf := leafSchema(schema, elems)
+	if leaf == nil {
+		return false
+	}
+	defaults := leaf.Default
+	if len(defaults) == 0 && leaf.Type != nil && leaf.Type.Default != "" {
+		defaults = []string{leaf.Type.Default}
+	}
+	if len(defaults) != 1 {
+		return false
+	}
+	return equalString(v, defaults[0])
+}
+
+// equalString reports whether v is equal to its YANG string representation s.
+func equalString(v interface{}, s string) bool {
+	if e, ok := v.(ygot.GoEnum); ok {
+		name, err := ygot.EnumName(e)
+		return err == nil && name == s
+	}
+	if n, ok := number(v); ok {
+		sn, ok := parseNumeric(s, n)
+		return ok && n == sn
+	}
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return false
+		}
+		val = val.Elem()
+	}
+	return fmt.Sprint(val.Interface()) == s
+}
+
+// numeric is a numeric value.  Integers are kept as int64 or uint64 so that
+// they compare exactly; only floating point values are float64.
+type numeric struct {
+	float bool
+	f     float64
+	// neg and mag are the sign and the magnitude of an integer.
+	neg bool
+	mag uint64
+}
+
+func intNumeric(i int64) numeric {
+	if i < 0 {
+		return numeric{neg: true, mag: uint64(-(i + 1)) + 1}
+	}
+	return numeric{mag: uint64(i)}
+}
+
+// toFloat returns the value as a float64.
+func (n numeric) toFloat() float64 {
+	switch {
+	case n.float:
+		return n.f
+	case n.neg:
+		return -float64(n.mag)
+	}
+	return float64(n.mag)
+}
+
+// within reports whether n and m differ by at most delta.
+func (n numeric) within(m numeric, delta float64) bool {
+	if n.float || m.float {
+		return math.Abs(n.toFloat()-m.toFloat()) <= delta
+	}
+	switch {
+	case delta < 0:
+		return false
+	case delta >= math.MaxUint64:
+		return true
+	}
+	var d uint64
+	switch {
+	case n.neg != m.neg:
+		d = n.mag + m.mag
+		if d < n.mag {
+			return false // The distance overflows uint64.
+		}
+	case n.mag >= m.mag:
+		d = n.mag - m.mag
+	default:
+		d = m.mag - n.mag
+	}
+	return d <= uint64(delta)
+}
+
+// number returns v as a numeric if it is numeric or a pointer to a number.
+func number(v interface{}) (numeric, bool) {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return numeric{}, false
+		}
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intNumeric(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numeric{mag: val.Uint()}, true
+	case reflect.Float32, reflect.Float64:
+		return numeric{float: true, f: val.Float()}, true
+	}
+	return numeric{}, false
+}
+
+// parseNumeric parses s as a number of the same kind as n.
+func parseNumeric(s string, n numeric) (numeric, bool) {
+	if n.float {
+		f, err := strconv.ParseFloat(s, 64)
+		return numeric{float: true, f: f}, err == nil
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return numeric{mag: u}, true
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	return intNumeric(i), err == nil
+}
+
+// timestamp returns v as nanoseconds since the Unix epoch if it is numeric or
+// an RFC 3339 string.
+func timestamp(v interface{}) (numeric, bool) {
+	if n, ok := number(v); ok {
+		return n, true
+	}
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case *string:
+		if v == nil {
+			return numeric{}, false
+		}
+		s = *v
+	default:
+		return numeric{}, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return numeric{}, false
+	}
+	return intNumeric(ts.UnixNano()), true
+}
+
+func withinTolerance(want, got interface{}, tol tolerance) bool {
+	conv := number
+	if tol.time {
+		conv = timestamp
+	}
+	w, ok := conv(want)
+	if !ok {
+		return false
+	}
+	g, ok := conv(got)
+	if !ok {
+		return false
+	}
+	return w.within(g, tol.delta)
+}
+
+// sameSet reports whether want and got are slices with the same elements.
+func sameSet(want, got interface{}) bool {
+	w, g := reflect.ValueOf(want), reflect.ValueOf(got)
+	if w.Kind() != reflect.Slice || g.Kind() != reflect.Slice {
+		return false
+	}
+	contains := func(s reflect.Value, v interface{}) bool {
+		for i := 0; i < s.Len(); i++ {
+			if reflect.DeepEqual(s.Index(i).Interface(), v) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := 0; i < w.Len(); i++ {
+		if !contains(g, w.Index(i).Interface()) {
+			return false
+		}
+	}
+	for i := 0; i < g.Len(); i++ {
+		if !contains(w, g.Index(i).Interface()) {
+			return false
+		}
+	}
+	return true
+}
@@ -47,6 +47,8 @@
 //     this file and updates to the tests where it is intended to be used.
 //   - Make sure the deviation defaults to false.  False (not deviated) means strictly
 //     compliant behavior.  True (deviated) activates the workaround.
+//   - Add the metadata of the flag in registry.go: the issue URL, the vendor, and
+//     the date added.
 //
 // To remove a deviation:
 //
@@ -54,6 +56,8 @@
 //     github issue by removing the deviation and it's usage within tests.
 //   - Typically the author or an affiliate of the author's organization
 //     is expected to remove a deviation they introduced.
+//   - Deviations without an open issue or metadata, unused deviations, and old
+//     deviations can be found with: go run ./tools/deviationreport
 //
 // To enable the deviations for a test run:
 //
@@ -61,6 +65,27 @@
 //     test invocation to set an argument to enable the deviation.
 //   - For example:
 //     go test my_test.go --deviation_interface_enabled=true
+//
+// To enable the deviations for a vendor platform using a profile:
+//
+//   - Write a DeviationProfile (see proto/deviations.proto) as a *.textproto file
+//     with the vendor, optional model and OS version regular expressions, and the
+//     deviation flag values.
+//   - Pass the directory of profiles to the test invocation, e.g.
+//     go test my_test.go --deviation_profile_dir=/path/to/profiles
+//   - After reservation, the most specific profile matching the DUT is applied.
+//     Deviation flags given on the command line take precedence over the profile.
+//   - The profile only applies to its DUT, as resolved by deviations.For(dut), so
+//     the DUTs of a multi-DUT testbed may have different profiles.  The deviation
+//     flags read directly are not changed by the profiles.
+//
+// To enable the deviations for one DUT of a multi-DUT testbed:
+//
+//   - Set the deviations of the device in the binding, e.g.
+//     deviations { key: "deviation_interface_enabled" value: "true" }
+//   - Tests and helpers resolve them with deviations.For(dut), e.g.
+//     deviations.For(dut).InterfaceEnabled(), which falls back to the command line
+//     flags and the profile of the DUT.
 package deviations
 
 import (
@@ -0,0 +1,278 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// Device is a device that may have its own deviations, e.g. *ondatra.DUTDevice.
+type Device interface {
+	Name() string
+}
+
+// deviceValues holds the deviation values of a device by the flag name.
+type deviceValues struct {
+	binding map[string]string // From the binding; takes precedence over everything.
+	profile map[string]string // From the profile, except flags given on the command line.
+}
+
+var (
+	devicesMu sync.RWMutex
+	devices   = make(map[string]*deviceValues)
+)
+
+// validate checks that the value can be set to the deviation flag.
+func validate(name, value string) error {
+	if !isDeviation(name) {
+		return fmt.Errorf("%q is not a deviation", name)
+	}
+	f := flag.Lookup(name)
+	if f == nil {
+		return fmt.Errorf("deviation %q is not a flag", name)
+	}
+	return checkValue(f, value)
+}
+
+// checkValue checks that the value is valid for the flag.
+func checkValue(f *flag.Flag, value string) error {
+	if g, ok := f.Value.(flag.Getter); ok {
+		if _, isBool := g.Get().(bool); isBool {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("deviation %q: invalid bool value %q", f.Name, value)
+			}
+		}
+	}
+	return nil
+}
+
+// SetDevice sets the deviations of a device by its name, typically from the
+// binding.  These take precedence over both the deviation flags and the profile of
+// the device.  The values are keyed by the flag name, e.g.
+// "deviation_interface_enabled".
+func SetDevice(name string, values map[string]string) error {
+	for k, v := range values {
+		if err := validate(k, v); err != nil {
+			return fmt.Errorf("device %s: %w", name, err)
+		}
+	}
+	devicesMu.Lock()
+	defer devicesMu.Unlock()
+	dv := device(name)
+	dv.binding = make(map[string]string)
+	for k, v := range values {
+		dv.binding[k] = v
+	}
+	return nil
+}
+
+// setDeviceProfile records the deviation profile of a device.
+func setDeviceProfile(name string, values map[string]string) {
+	devicesMu.Lock()
+	defer devicesMu.Unlock()
+	device(name).profile = values
+}
+
+// device returns the deviceValues for the name; devicesMu must be held for writing.
+func device(name string) *deviceValues {
+	dv, ok := devices[name]
+	if !ok {
+		dv = &deviceValues{}
+		devices[name] = dv
+	}
+	return dv
+}
+
+// Deviations resolves the deviations of a device.  A value set for the device in the
+// binding comes first, then the deviation profile selected for the device, and
+// finally the deviation flag, given on the command line or by default.  The profile
+// has no values for the flags given on the command line.
+type Deviations struct {
+	name string
+}
+
+// For returns the deviations of a device, e.g. deviations.For(dut).InterfaceEnabled().
+// A nil device resolves to the deviation flags.
+func For(d Device) *Deviations {
+	if d == nil || isNilPointer(d) {
+		return &Deviations{}
+	}
+	return &Deviations{name: d.Name()}
+}
+
+// isNilPointer returns true for a typed nil, e.g. (*ondatra.DUTDevice)(nil).
+func isNilPointer(d Device) bool {
+	v := reflect.ValueOf(d)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// lookup returns the value for the device, or false if the flag value applies.
+func (d *Deviations) lookup(name string) (string, bool) {
+	if d == nil || d.name == "" {
+		return "", false
+	}
+	devicesMu.RLock()
+	defer devicesMu.RUnlock()
+	dv, ok := devices[d.name]
+	if !ok {
+		return "", false
+	}
+	if v, ok := dv.binding[name]; ok {
+		return v, true
+	}
+	// Profile values exclude the flags given on the command line.
+	v, ok := dv.profile[name]
+	return v, ok
+}
+
+func (d *Deviations) boolValue(name string, global bool) bool {
+	if v, ok := d.lookup(name); ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return global
+}
+
+func (d *Deviations) stringValue(name string, global string) string {
+	if v, ok := d.lookup(name); ok {
+		return v
+	}
+	return global
+}
+
+// BannerDelimiter resolves --deviation_banner_delimiter for the device.
+func (d *Deviations) BannerDelimiter() string {
+	return d.stringValue("deviation_banner_delimiter", *BannerDelimiter)
+}
+
+// NTPAssociationTypeRequired resolves --deviation_ntp_association_type_required for the device.
+func (d *Deviations) NTPAssociationTypeRequired() bool {
+	return d.boolValue("deviation_ntp_association_type_required", *NTPAssociationTypeRequired)
+}
+
+// InterfaceEnabled resolves --deviation_interface_enabled for the device.
+func (d *Deviations) InterfaceEnabled() bool {
+	return d.boolValue("deviation_interface_enabled", *InterfaceEnabled)
+}
+
+// InterfaceOperStatus resolves --deviation_interface_operstatus for the device.
+func (d *Deviations) InterfaceOperStatus() bool {
+	return d.boolValue("deviation_interface_operstatus", *InterfaceOperStatus)
+}
+
+// IPv4MissingEnabled resolves --deviation_ipv4_missing_enabled for the device.
+func (d *Deviations) IPv4MissingEnabled() bool {
+	return d.boolValue("deviation_ipv4_missing_enabled", *IPv4MissingEnabled)
+}
+
+// IPNeighborMissing resolves --deviation_ip_neighbor_missing for the device.
+func (d *Deviations) IPNeighborMissing() bool {
+	return d.boolValue("deviation_ip_neighbor_missing", *IPNeighborMissing)
+}
+
+// InterfaceCountersFromContainer resolves --deviation_interface_counters_from_container for the device.
+func (d *Deviations) InterfaceCountersFromContainer() bool {
+	return d.boolValue("deviation_interface_counters_from_container", *InterfaceCountersFromContainer)
+}
+
+// AggregateAtomicUpdate resolves --deviation_aggregate_atomic_update for the device.
+func (d *Deviations) AggregateAtomicUpdate() bool {
+	return d.boolValue("deviation_aggregate_atomic_update", *AggregateAtomicUpdate)
+}
+
+// DefaultNetworkInstance resolves --deviation_default_network_instance for the device.
+func (d *Deviations) DefaultNetworkInstance() string {
+	return d.stringValue("deviation_default_network_instance", *DefaultNetworkInstance)
+}
+
+// SubinterfacePacketCountersMissing resolves --deviation_subinterface_packet_counters_missing for the device.
+func (d *Deviations) SubinterfacePacketCountersMissing() bool {
+	return d.boolValue("deviation_subinterface_packet_counters_missing", *SubinterfacePacketCountersMissing)
+}
+
+// OmitL2MTU resolves --deviation_omit_l2_mtu for the device.
+func (d *Deviations) OmitL2MTU() bool {
+	return d.boolValue("deviation_omit_l2_mtu", *OmitL2MTU)
+}
+
+// GRIBIPreserveOnly resolves --deviation_gribi_preserve_only for the device.
+func (d *Deviations) GRIBIPreserveOnly() bool {
+	return d.boolValue("deviation_gribi_preserve_only", *GRIBIPreserveOnly)
+}
+
+// GRIBIRIBAckOnly resolves --deviation_gribi_riback_only for the device.
+func (d *Deviations) GRIBIRIBAckOnly() bool {
+	return d.boolValue("deviation_gribi_riback_only", *GRIBIRIBAckOnly)
+}
+
+// MissingValueForDefaults resolves --deviation_missing_value_for_defaults for the device.
+func (d *Deviations) MissingValueForDefaults() bool {
+	return d.boolValue("deviation_missing_value_for_defaults", *MissingValueForDefaults)
+}
+
+// StaticProtocolName resolves --deviation_static_protocol_name for the device.
+func (d *Deviations) StaticProtocolName() string {
+	return d.stringValue("deviation_static_protocol_name", *StaticProtocolName)
+}
+
+// GNOISubcomponentPath resolves --deviation_gnoi_subcomponent_path for the device.
+func (d *Deviations) GNOISubcomponentPath() bool {
+	return d.boolValue("deviation_gnoi_subcomponent_path", *GNOISubcomponentPath)
+}
+
+// GNOIStatusWithEmptySubcomponent resolves --deviation_gnoi_status_empty_subcomponent for the device.
+func (d *Deviations) GNOIStatusWithEmptySubcomponent() bool {
+	return d.boolValue("deviation_gnoi_status_empty_subcomponent", *GNOIStatusWithEmptySubcomponent)
+}
+
+// DeprecatedVlanID resolves --deviation_deprecated_vlan_id for the device.
+func (d *Deviations) DeprecatedVlanID() bool {
+	return d.boolValue("deviation_deprecated_vlan_id", *DeprecatedVlanID)
+}
+
+// ExplicitInterfaceInDefaultVRF resolves --deviation_explicit_interface_in_default_vrf for the device.
+func (d *Deviations) ExplicitInterfaceInDefaultVRF() bool {
+	return d.boolValue("deviation_explicit_interface_in_default_vrf", *ExplicitInterfaceInDefaultVRF)
+}
+
+// ExplicitPortSpeed resolves --deviation_explicit_port_speed for the device.
+func (d *Deviations) ExplicitPortSpeed() bool {
+	return d.boolValue("deviation_explicit_port_speed", *ExplicitPortSpeed)
+}
+
+// ExplicitP4RTNodeComponent resolves --deviation_explicit_p4rt_node_component for the device.
+func (d *Deviations) ExplicitP4RTNodeComponent() bool {
+	return d.boolValue("deviation_explicit_p4rt_node_component", *ExplicitP4RTNodeComponent)
+}
+
+// RoutePolicyUnderPeerGroup resolves --deviation_rpl_under_peergroup for the device.
+func (d *Deviations) RoutePolicyUnderPeerGroup() bool {
+	return d.boolValue("deviation_rpl_under_peergroup", *RoutePolicyUnderPeerGroup)
+}
+
+// MissingPrePolicyReceivedRoutes resolves --deviation_prepolicy_received_routes for the device.
+func (d *Deviations) MissingPrePolicyReceivedRoutes() bool {
+	return d.boolValue("deviation_prepolicy_received_routes", *MissingPrePolicyReceivedRoutes)
+}
+
+// RoutePolicyUnderNeighborAfiSafi resolves --deviation_rpl_under_neighbor_afisafi for the device.
+func (d *Deviations) RoutePolicyUnderNeighborAfiSafi() bool {
+	return d.boolValue("deviation_rpl_under_neighbor_afisafi", *RoutePolicyUnderNeighborAfiSafi)
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+type fakeDevice string
+
+func (d fakeDevice) Name() string { return string(d) }
+
+type ptrDevice struct{}
+
+func (d *ptrDevice) Name() string { return "ptr" }
+
+func TestSetDevice_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		values map[string]string
+	}{
+		{name: "NotDeviation", values: map[string]string{"binding": "x"}},
+		{name: "ProfileDir", values: map[string]string{"deviation_profile_dir": "x"}},
+		{name: "BadBool", values: map[string]string{"deviation_interface_enabled": "yes please"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := SetDevice("dut.errors", c.values); err == nil {
+				t.Errorf("SetDevice(%v) got no error", c.values)
+			}
+		})
+	}
+}
+
+func TestFor(t *testing.T) {
+	if err := SetDevice("dut1", map[string]string{
+		"deviation_interface_enabled":        "true",
+		"deviation_default_network_instance": "default",
+	}); err != nil {
+		t.Fatalf("SetDevice got error: %v", err)
+	}
+	setDeviceProfile("dut1", map[string]string{
+		"deviation_interface_enabled": "false",
+		"deviation_omit_l2_mtu":       "true",
+	})
+	setDeviceProfile("dut2", map[string]string{
+		"deviation_omit_l2_mtu": "true",
+	})
+
+	dut1 := For(fakeDevice("dut1"))
+	if !dut1.InterfaceEnabled() {
+		t.Errorf("dut1 InterfaceEnabled() got false, want true from the binding")
+	}
+	if got, want := dut1.DefaultNetworkInstance(), "default"; got != want {
+		t.Errorf("dut1 DefaultNetworkInstance() got %q, want %q", got, want)
+	}
+	if !dut1.OmitL2MTU() {
+		t.Errorf("dut1 OmitL2MTU() got false, want true from the profile")
+	}
+	if got, want := dut1.StaticProtocolName(), *StaticProtocolName; got != want {
+		t.Errorf("dut1 StaticProtocolName() got %q, want flag value %q", got, want)
+	}
+
+	dut2 := For(fakeDevice("dut2"))
+	if got, want := dut2.InterfaceEnabled(), *InterfaceEnabled; got != want {
+		t.Errorf("dut2 InterfaceEnabled() got %v, want flag value %v", got, want)
+	}
+	if !dut2.OmitL2MTU() {
+		t.Errorf("dut2 OmitL2MTU() got false, want true from the profile")
+	}
+
+	for _, d := range []*Deviations{For(nil), For((*ptrDevice)(nil)), For(fakeDevice("unknown"))} {
+		if got, want := d.DefaultNetworkInstance(), *DefaultNetworkInstance; got != want {
+			t.Errorf("DefaultNetworkInstance() got %q, want flag value %q", got, want)
+		}
+	}
+}
+
+// TestForMethods checks that every method of Deviations resolves a registered flag.
+func TestForMethods(t *testing.T) {
+	values := make(map[string]string)
+	for _, dev := range Registry() {
+		name, f := dev.Name, dev.Flag
+		if g, ok := f.Value.(flag.Getter); ok {
+			if _, isBool := g.Get().(bool); isBool {
+				values[name] = "true"
+				continue
+			}
+		}
+		values[name] = "custom"
+	}
+	if err := SetDevice("dut.methods", values); err != nil {
+		t.Fatalf("SetDevice got error: %v", err)
+	}
+
+	d := reflect.ValueOf(For(fakeDevice("dut.methods")))
+	typ := d.Type()
+	if got, want := typ.NumMethod(), len(values); got != want {
+		t.Errorf("Deviations has %d methods, want one for each of the %d deviations", got, want)
+	}
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		switch got := d.Method(i).Call(nil)[0].Interface().(type) {
+		case bool:
+			if !got {
+				t.Errorf("%s() got false, want true", m.Name)
+			}
+		case string:
+			if got != "custom" {
+				t.Errorf("%s() got %q, want %q", m.Name, got, "custom")
+			}
+		default:
+			t.Errorf("%s() returns unexpected type %T", m.Name, got)
+		}
+	}
+}
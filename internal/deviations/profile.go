@@ -0,0 +1,239 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	dpb "github.com/openconfig/featureprofiles/proto/deviations_go_proto"
+)
+
+var (
+	profileDir = flag.String("deviation_profile_dir", "",
+		"Directory of deviation profiles as *.textproto files.  The profile matching the vendor, model, and OS version of the DUT is applied after reservation.  Deviation flags given on the command line take precedence over the profile.")
+)
+
+// profileExt is the file extension of the deviation profiles.
+const profileExt = ".textproto"
+
+// profile is a deviation profile loaded from a file.
+type profile struct {
+	file      string
+	pb        *dpb.DeviationProfile
+	model     *regexp.Regexp
+	osVersion *regexp.Regexp
+}
+
+// anchor compiles a regular expression that must match the whole string.
+func anchor(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + expr + ")$")
+}
+
+// newProfile validates the deviation profile and compiles its regular expressions.
+func newProfile(file string, pb *dpb.DeviationProfile) (*profile, error) {
+	if pb.GetVendor() == "" {
+		return nil, fmt.Errorf("profile %s: missing vendor", file)
+	}
+	model, err := anchor(pb.GetModel())
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: bad model: %w", file, err)
+	}
+	osVersion, err := anchor(pb.GetOsVersion())
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: bad os_version: %w", file, err)
+	}
+	for name := range pb.GetFlags() {
+		if !isDeviation(name) {
+			return nil, fmt.Errorf("profile %s: flag %q is not a deviation", file, name)
+		}
+	}
+	return &profile{file: file, pb: pb, model: model, osVersion: osVersion}, nil
+}
+
+// loadProfiles reads all deviation profiles from a directory, ordered by file name.
+func loadProfiles(dir string) ([]*profile, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+profileExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var profiles []*profile
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read profile: %w", err)
+		}
+		pb := &dpb.DeviationProfile{}
+		if err := prototext.Unmarshal(data, pb); err != nil {
+			return nil, fmt.Errorf("unable to parse profile %s: %w", file, err)
+		}
+		p, err := newProfile(file, pb)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// matches returns true if the profile applies to the given device.
+func (p *profile) matches(vendor, model, osVersion string) bool {
+	if !strings.EqualFold(p.pb.GetVendor(), vendor) {
+		return false
+	}
+	if p.model != nil && !p.model.MatchString(model) {
+		return false
+	}
+	if p.osVersion != nil && !p.osVersion.MatchString(osVersion) {
+		return false
+	}
+	return true
+}
+
+// specificity counts how narrowly the profile selects a device.
+func (p *profile) specificity() int {
+	n := 0
+	if p.model != nil {
+		n++
+	}
+	if p.osVersion != nil {
+		n++
+	}
+	return n
+}
+
+// selectProfile returns the most specific profile that matches the device, or nil if
+// no profile matches.  It is an error if more than one profile is equally specific.
+func selectProfile(profiles []*profile, vendor, model, osVersion string) (*profile, error) {
+	var best []*profile
+	for _, p := range profiles {
+		if !p.matches(vendor, model, osVersion) {
+			continue
+		}
+		switch {
+		case len(best) == 0 || p.specificity() > best[0].specificity():
+			best = []*profile{p}
+		case p.specificity() == best[0].specificity():
+			best = append(best, p)
+		}
+	}
+	switch len(best) {
+	case 0:
+		return nil, nil
+	case 1:
+		return best[0], nil
+	}
+	var files []string
+	for _, p := range best {
+		files = append(files, p.file)
+	}
+	return nil, fmt.Errorf("ambiguous deviation profiles for vendor %q, model %q, os version %q: %s",
+		vendor, model, osVersion, strings.Join(files, ", "))
+}
+
+// profileState knows the flags given on the command line, which profiles do not
+// change.
+type profileState struct {
+	fs       *flag.FlagSet
+	explicit map[string]bool // flags given on the command line.
+}
+
+func newProfileState(fs *flag.FlagSet) *profileState {
+	ps := &profileState{
+		fs:       fs,
+		explicit: make(map[string]bool),
+	}
+	fs.Visit(func(f *flag.Flag) { ps.explicit[f.Name] = true })
+	return ps
+}
+
+// apply returns the profile values that apply to the device, i.e. those of the
+// flags not given on the command line.  The deviation flags themselves are not
+// changed, since they are shared by all devices.
+func (ps *profileState) apply(p *profile) (map[string]string, error) {
+	values := make(map[string]string)
+	for name, value := range p.pb.GetFlags() {
+		f := ps.fs.Lookup(name)
+		if f == nil {
+			return nil, fmt.Errorf("profile %s: unknown flag %q", p.file, name)
+		}
+		if ps.explicit[name] {
+			glog.Infof("Deviation profile %s: keeping explicit --%s=%s", p.file, name, f.Value)
+			continue
+		}
+		if err := checkValue(f, value); err != nil {
+			return nil, fmt.Errorf("profile %s: %w", p.file, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+var (
+	profilesOnce sync.Once
+	profiles     []*profile
+	profilesErr  error
+	state        *profileState
+)
+
+// HasProfiles returns true if --deviation_profile_dir is given, i.e. if ApplyProfile
+// needs the vendor, model, and OS version of the devices.
+func HasProfiles() bool {
+	return *profileDir != ""
+}
+
+// ApplyProfile selects the deviation profile from --deviation_profile_dir that matches
+// the vendor, model, and OS version of the named device.  The profile sets the
+// deviations of the device resolved by For, except those of the flags given on the
+// command line.  The deviation flags are not changed.  It returns the file name of the profile applied,
+// or an empty string if there is no matching profile.
+func ApplyProfile(name, vendor, model, osVersion string) (string, error) {
+	if !HasProfiles() {
+		return "", nil
+	}
+	profilesOnce.Do(func() {
+		state = newProfileState(flag.CommandLine)
+		profiles, profilesErr = loadProfiles(*profileDir)
+	})
+	if profilesErr != nil {
+		return "", profilesErr
+	}
+	p, err := selectProfile(profiles, vendor, model, osVersion)
+	if err != nil || p == nil {
+		return "", err
+	}
+	values, err := state.apply(p)
+	if err != nil {
+		return "", err
+	}
+	setDeviceProfile(name, values)
+	glog.Infof("Applied deviation profile %s to %s for vendor %q, model %q, os version %q",
+		p.file, name, vendor, model, osVersion)
+	return p.file, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	dpb "github.com/openconfig/featureprofiles/proto/deviations_go_proto"
+)
+
+func dpbProfile(flags map[string]string) *dpb.DeviationProfile {
+	return &dpb.DeviationProfile{Vendor: "ARISTA", Flags: flags}
+}
+
+func writeProfiles(t *testing.T, profiles map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range profiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Could not write profile %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestSelectProfile(t *testing.T) {
+	dir := writeProfiles(t, map[string]string{
+		"arista.textproto": `
+vendor: "ARISTA"
+flags { key: "deviation_interface_enabled" value: "true" }
+`,
+		"arista_7280.textproto": `
+vendor: "arista"
+model: "7280.*"
+flags { key: "deviation_interface_enabled" value: "false" }
+`,
+		"arista_7280_4.29.textproto": `
+vendor: "ARISTA"
+model: "7280.*"
+os_version: "4\\.29\\..*"
+`,
+		"cisco_8000.textproto": `
+vendor: "CISCO"
+model: "8.*"
+`,
+		"cisco_7.textproto": `
+vendor: "CISCO"
+os_version: "7\\..*"
+`,
+		"ignored.txt": `not a profile`,
+	})
+	profiles, err := loadProfiles(dir)
+	if err != nil {
+		t.Fatalf("loadProfiles got error: %v", err)
+	}
+	if got, want := len(profiles), 5; got != want {
+		t.Fatalf("loadProfiles got %d profiles, want %d", got, want)
+	}
+
+	cases := []struct {
+		vendor, model, osVersion string
+		want                     string
+		wantErr                  bool
+	}{
+		{vendor: "ARISTA", model: "7050", osVersion: "4.29.1F", want: "arista.textproto"},
+		{vendor: "ARISTA", model: "7280R3", osVersion: "4.28.0F", want: "arista_7280.textproto"},
+		{vendor: "ARISTA", model: "7280R3", osVersion: "4.29.1F", want: "arista_7280_4.29.textproto"},
+		{vendor: "ARISTA", model: "x7280", osVersion: "4.29.1F", want: "arista.textproto"},
+		{vendor: "CISCO", model: "8808", osVersion: "7.7.1", wantErr: true},
+		{vendor: "CISCO", model: "ASR9K", osVersion: "7.7.1", want: "cisco_7.textproto"},
+		{vendor: "JUNIPER", model: "PTX10008", osVersion: "22.2R1"},
+	}
+	for _, c := range cases {
+		got, err := selectProfile(profiles, c.vendor, c.model, c.osVersion)
+		if (err != nil) != c.wantErr {
+			t.Errorf("selectProfile(%q, %q, %q) got error %v, want error %v", c.vendor, c.model, c.osVersion, err, c.wantErr)
+			continue
+		}
+		var gotFile string
+		if got != nil {
+			gotFile = filepath.Base(got.file)
+		}
+		if gotFile != c.want {
+			t.Errorf("selectProfile(%q, %q, %q) got %q, want %q", c.vendor, c.model, c.osVersion, gotFile, c.want)
+		}
+	}
+}
+
+func TestLoadProfiles_Errors(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+	}{
+		{name: "NoVendor", content: `model: "x"`},
+		{name: "BadModel", content: `vendor: "ARISTA" model: "("`},
+		{name: "BadOSVersion", content: `vendor: "ARISTA" os_version: "["`},
+		{name: "NotDeviation", content: `vendor: "ARISTA" flags { key: "binding" value: "x" }`},
+		{name: "Syntax", content: `vendor ARISTA`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := writeProfiles(t, map[string]string{"p.textproto": c.content})
+			if _, err := loadProfiles(dir); err == nil {
+				t.Errorf("loadProfiles got no error, want error")
+			}
+		})
+	}
+}
+
+func TestProfileStateApply(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	enabled := fs.Bool("deviation_interface_enabled", false, "")
+	omitMTU := fs.Bool("deviation_omit_l2_mtu", false, "")
+	name := fs.String("deviation_default_network_instance", "DEFAULT", "")
+	if err := fs.Parse([]string{"--deviation_omit_l2_mtu=false"}); err != nil {
+		t.Fatalf("Could not parse flags: %v", err)
+	}
+	ps := newProfileState(fs)
+
+	p1, err := newProfile("p1", dpbProfile(map[string]string{
+		"deviation_interface_enabled":        "true",
+		"deviation_omit_l2_mtu":              "true",
+		"deviation_default_network_instance": "default",
+	}))
+	if err != nil {
+		t.Fatalf("newProfile got error: %v", err)
+	}
+	values, err := ps.apply(p1)
+	if err != nil {
+		t.Fatalf("apply got error: %v", err)
+	}
+	wantValues := map[string]string{
+		"deviation_interface_enabled":        "true",
+		"deviation_default_network_instance": "default",
+	}
+	if diff := cmp.Diff(wantValues, values); diff != "" {
+		t.Errorf("apply values diff (-want +got):\n%s", diff)
+	}
+	if *enabled || *omitMTU || *name != "DEFAULT" {
+		t.Errorf("apply changed the flags to %v, %v, %q, want them unchanged", *enabled, *omitMTU, *name)
+	}
+
+	// Another device may have a profile with different values.
+	other, _ := newProfile("other", dpbProfile(map[string]string{
+		"deviation_default_network_instance": "DEFAULT",
+		"deviation_interface_enabled":        "false",
+	}))
+	values, err = ps.apply(other)
+	if err != nil {
+		t.Errorf("apply with different values got error: %v", err)
+	}
+	wantValues = map[string]string{
+		"deviation_interface_enabled":        "false",
+		"deviation_default_network_instance": "DEFAULT",
+	}
+	if diff := cmp.Diff(wantValues, values); diff != "" {
+		t.Errorf("apply values diff (-want +got):\n%s", diff)
+	}
+	invalid, _ := newProfile("invalid", dpbProfile(map[string]string{"deviation_interface_enabled": "maybe"}))
+	if _, err := ps.apply(invalid); err == nil {
+		t.Errorf("apply with an invalid bool value got no error")
+	}
+	missing, _ := newProfile("missing", dpbProfile(map[string]string{"deviation_ntp_association_type_required": "true"}))
+	if _, err := ps.apply(missing); err == nil {
+		t.Errorf("apply with a flag missing from the flag set got no error")
+	}
+	if _, err := newProfile("unknown", dpbProfile(map[string]string{"deviation_unknown": "true"})); err == nil {
+		t.Errorf("newProfile with an unknown deviation got no error")
+	}
+	if _, err := newProfile("dir", dpbProfile(map[string]string{"deviation_profile_dir": "/tmp"})); err == nil {
+		t.Errorf("newProfile with --deviation_profile_dir got no error")
+	}
+}
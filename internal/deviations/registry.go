@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"flag"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of Metadata.Added.
+const DateLayout = "2006-01-02"
+
+// Metadata describes why a deviation exists and what it affects.
+type Metadata struct {
+	// IssueURL is the GitHub issue explaining the need for the deviation.
+	IssueURL string `json:"issue_url,omitempty"`
+
+	// Vendor is the vendor who introduced the deviation, e.g. "ARISTA".
+	Vendor string `json:"vendor,omitempty"`
+
+	// Added is the date when the deviation was introduced, formatted as
+	// YYYY-MM-DD (see DateLayout).
+	Added string `json:"added,omitempty"`
+}
+
+// AddedTime parses the date when the deviation was introduced.  It returns the
+// zero time if the date is unknown.
+func (md *Metadata) AddedTime() (time.Time, error) {
+	if md.Added == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(DateLayout, md.Added)
+}
+
+// Deviation is a deviation flag with its metadata.
+type Deviation struct {
+	*flag.Flag
+	Metadata
+}
+
+// Registry returns all deviations ordered by the flag name.  The deviations are the
+// deviation flags themselves, so the registry cannot miss one.
+func Registry() []Deviation {
+	var devs []Deviation
+	flag.VisitAll(func(f *flag.Flag) {
+		if isDeviationFlag(f.Name) {
+			devs = append(devs, Deviation{Flag: f, Metadata: metadata[f.Name]})
+		}
+	})
+	sort.Slice(devs, func(i, j int) bool { return devs[i].Name < devs[j].Name })
+	return devs
+}
+
+// isDeviationFlag returns true if the flag name is the name of a deviation flag,
+// whether or not the flag is defined.
+func isDeviationFlag(name string) bool {
+	return strings.HasPrefix(name, "deviation_") && name != "deviation_profile_dir"
+}
+
+// isDeviation returns true if the flag name is a defined deviation flag.
+func isDeviation(name string) bool {
+	return isDeviationFlag(name) && flag.Lookup(name) != nil
+}
+
+// metadata is the metadata of the deviation flags, keyed by the flag name.  Every
+// deviation flag must have its issue, vendor, and date here, except the legacy
+// deviations below; TestRegistry checks both against the flags.  The affected feature
+// profiles are not listed here; tools/deviationreport finds them from the uses of the
+// deviation.
+var metadata = map[string]Metadata{}
+
+// legacy are the deviations which predate the registry.  Their issue, vendor, and
+// date are only recorded in the pull requests that introduced them; a deviation is
+// removed from this list when its metadata is added.  No deviation may be added to
+// this list: a new deviation comes with its metadata.
+var legacy = map[string]bool{
+	"deviation_aggregate_atomic_update":              true,
+	"deviation_banner_delimiter":                     true,
+	"deviation_default_network_instance":             true,
+	"deviation_deprecated_vlan_id":                   true,
+	"deviation_explicit_interface_in_default_vrf":    true,
+	"deviation_explicit_p4rt_node_component":         true,
+	"deviation_explicit_port_speed":                  true,
+	"deviation_gnoi_status_empty_subcomponent":       true,
+	"deviation_gnoi_subcomponent_path":               true,
+	"deviation_gribi_preserve_only":                  true,
+	"deviation_gribi_riback_only":                    true,
+	"deviation_interface_counters_from_container":    true,
+	"deviation_interface_enabled":                    true,
+	"deviation_interface_operstatus":                 true,
+	"deviation_ip_neighbor_missing":                  true,
+	"deviation_ipv4_missing_enabled":                 true,
+	"deviation_missing_value_for_defaults":           true,
+	"deviation_ntp_association_type_required":        true,
+	"deviation_omit_l2_mtu":                          true,
+	"deviation_prepolicy_received_routes":            true,
+	"deviation_rpl_under_neighbor_afisafi":           true,
+	"deviation_rpl_under_peergroup":                  true,
+	"deviation_static_protocol_name":                 true,
+	"deviation_subinterface_packet_counters_missing": true,
+}
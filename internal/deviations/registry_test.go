@@ -0,0 +1,64 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deviations
+
+import (
+	"flag"
+	"net/url"
+	"testing"
+)
+
+// TestRegistry checks that every deviation flag has valid metadata, unless it is a
+// legacy deviation, and that the metadata and the legacy deviations are flags.
+func TestRegistry(t *testing.T) {
+	flags := make(map[string]bool)
+	flag.VisitAll(func(f *flag.Flag) {
+		if !isDeviationFlag(f.Name) {
+			return
+		}
+		flags[f.Name] = true
+		md, ok := metadata[f.Name]
+		switch {
+		case legacy[f.Name] && ok:
+			t.Errorf("Deviation %q has metadata; remove it from the legacy deviations.", f.Name)
+		case legacy[f.Name]:
+		case md.IssueURL == "" || md.Vendor == "" || md.Added == "":
+			t.Errorf("Deviation %q is missing its issue URL, vendor, or date: %+v", f.Name, md)
+		}
+	})
+
+	for name, md := range metadata {
+		if !flags[name] {
+			t.Errorf("Deviation %q with metadata is not a flag.", name)
+		}
+		if _, err := md.AddedTime(); err != nil {
+			t.Errorf("Deviation %q has bad date: %v", name, err)
+		}
+		if md.IssueURL != "" {
+			if u, err := url.Parse(md.IssueURL); err != nil || u.Scheme != "https" {
+				t.Errorf("Deviation %q has bad issue URL: %q", name, md.IssueURL)
+			}
+		}
+	}
+	for name := range legacy {
+		if !flags[name] {
+			t.Errorf("Legacy deviation %q is not a flag.", name)
+		}
+	}
+
+	if got, want := len(Registry()), len(flags); got != want {
+		t.Errorf("Registry() got %d deviations, want %d", got, want)
+	}
+}
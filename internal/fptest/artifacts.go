@@ -0,0 +1,282 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// ArtifactKind is the format of an artifact.
+type ArtifactKind string
+
+// Kinds of artifacts.
+const (
+	ArtifactJSON      ArtifactKind = "json"
+	ArtifactTextproto ArtifactKind = "textproto"
+	ArtifactPCAP      ArtifactKind = "pcap"
+	ArtifactCSV       ArtifactKind = "csv"
+	ArtifactText      ArtifactKind = "txt"
+)
+
+// manifestFile lists the artifacts in each test directory.
+const manifestFile = "manifest.json"
+
+// ManifestEntry describes an artifact in the manifest.json of a test directory.
+type ManifestEntry struct {
+	File string       `json:"file"`
+	Test string       `json:"test"`
+	Kind ArtifactKind `json:"kind"`
+	Time time.Time    `json:"time"`
+	Gzip bool         `json:"gzip,omitempty"`
+}
+
+var (
+	// manifestMu guards the manifests written by this process.
+	manifestMu sync.Mutex
+	manifests  = make(map[string][]*ManifestEntry)
+
+	// testPackage is the import path of the package of the tests, set by
+	// RunTests.  The test directories are keyed by it, so that the packages
+	// which run in parallel with the same -outputs_dir each write their own
+	// manifest.json.
+	testPackage string
+)
+
+// ArtifactStore writes the artifacts of a test into its own directory under
+// -outputs_dir.  The directory of a test is nested in the directories of its
+// package, and the directory of a subtest in the directory of its parent
+// test.  Without -outputs_dir, the artifacts are discarded.
+type ArtifactStore struct {
+	test string
+	dir  string
+	gzip bool
+}
+
+// Artifacts returns the artifact store of the test.
+func Artifacts(t testing.TB) *ArtifactStore {
+	return newArtifactStore(*outputsDir, testPackage, t.Name())
+}
+
+func newArtifactStore(outputsDir, pkg, test string) *ArtifactStore {
+	a := &ArtifactStore{test: test}
+	if outputsDir == "" {
+		return a
+	}
+	parts := []string{outputsDir}
+	if pkg != "" {
+		for _, name := range strings.Split(pkg, "/") {
+			parts = append(parts, sanitizeFilename(name))
+		}
+	}
+	for _, name := range strings.Split(test, "/") {
+		parts = append(parts, sanitizeFilename(name))
+	}
+	a.dir = filepath.Join(parts...)
+	return a
+}
+
+// packagePath returns the import path of the package of a function, relative
+// to the module if it is in the module.
+func packagePath(function, module string) string {
+	i := strings.LastIndex(function, "/") + 1
+	if j := strings.Index(function[i:], "."); j >= 0 {
+		function = function[:i+j]
+	}
+	if module != "" {
+		function = strings.TrimPrefix(function, module+"/")
+	}
+	return function
+}
+
+// Dir returns the directory of the test, or "" without -outputs_dir.
+func (a *ArtifactStore) Dir() string {
+	return a.dir
+}
+
+// Gzip returns a copy of the store which compresses the artifacts it writes.
+func (a *ArtifactStore) Gzip() *ArtifactStore {
+	gz := *a
+	gz.gzip = true
+	return &gz
+}
+
+// WriteJSON writes v as indented JSON.  A json.RawMessage is reindented.
+func (a *ArtifactStore) WriteJSON(name string, v interface{}) (string, error) {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal artifact %q: %w", name, err)
+	}
+	return a.Write(name, ArtifactJSON, content)
+}
+
+// WriteTextproto writes m in the protobuf text format.
+func (a *ArtifactStore) WriteTextproto(name string, m proto.Message) (string, error) {
+	content, err := prototext.MarshalOptions{Multiline: true}.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal artifact %q: %w", name, err)
+	}
+	return a.Write(name, ArtifactTextproto, content)
+}
+
+// WritePCAP writes a packet capture, e.g. from ate.OTG().GetCapture().
+func (a *ArtifactStore) WritePCAP(name string, capture []byte) (string, error) {
+	return a.Write(name, ArtifactPCAP, capture)
+}
+
+// WriteCSV writes the records as CSV.
+func (a *ArtifactStore) WriteCSV(name string, records [][]string) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return "", fmt.Errorf("cannot encode artifact %q: %w", name, err)
+	}
+	return a.Write(name, ArtifactCSV, buf.Bytes())
+}
+
+// Write writes the content of an artifact of the given kind, and records it in
+// the manifest.json of the test directory.  The file name is the sanitized name
+// with the extension of the kind, made unique within the directory.  It returns
+// the path of the file, or "" without -outputs_dir.
+func (a *ArtifactStore) Write(name string, kind ArtifactKind, content []byte) (string, error) {
+	if a.dir == "" {
+		log.Printf("Test artifact %q of %s is discarded without -outputs_dir.  Please specify -outputs_dir to keep it.", name, a.test)
+		return "", nil
+	}
+	if a.gzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Name = sanitizeFilename(name) + "." + string(kind)
+		if _, err := zw.Write(content); err != nil {
+			return "", err
+		}
+		if err := zw.Close(); err != nil {
+			return "", err
+		}
+		content = buf.Bytes()
+	}
+
+	manifestMu.Lock()
+	defer manifestMu.Unlock()
+
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return "", err
+	}
+	f, err := a.create(name, kind)
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Test artifact written: %s", f.Name())
+
+	entry := &ManifestEntry{
+		File: filepath.Base(f.Name()),
+		Test: a.test,
+		Kind: kind,
+		Time: time.Now(),
+		Gzip: a.gzip,
+	}
+	if _, ok := manifests[a.dir]; !ok {
+		manifests[a.dir] = readManifest(a.dir)
+	}
+	manifests[a.dir] = append(manifests[a.dir], entry)
+	if err := writeManifest(a.dir, manifests[a.dir]); err != nil {
+		return f.Name(), fmt.Errorf("cannot update the manifest: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// create creates a new file for the artifact, adding a counter to the name if
+// the file already exists.
+func (a *ArtifactStore) create(name string, kind ArtifactKind) (*os.File, error) {
+	base := sanitizeFilename(name)
+	if base == "" {
+		base = string(kind)
+	}
+	ext := "." + string(kind)
+	if a.gzip {
+		ext += ".gz"
+	}
+	for i := 0; ; i++ {
+		filename := base + ext
+		if i > 0 {
+			filename = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+		if filename == manifestFile {
+			continue
+		}
+		f, err := os.OpenFile(filepath.Join(a.dir, filename), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+}
+
+// readManifest reads the entries left in a test directory by a previous run.
+func readManifest(dir string) []*ManifestEntry {
+	content, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil
+	}
+	var entries []*ManifestEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		log.Printf("Ignoring the invalid manifest in %s: %v", dir, err)
+		return nil
+	}
+	return entries
+}
+
+// writeManifest replaces the manifest.json of a test directory.
+func writeManifest(dir string, entries []*ManifestEntry) error {
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, manifestFile+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(content)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, manifestFile))
+}
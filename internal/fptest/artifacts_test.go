@@ -0,0 +1,133 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestArtifactStore(t *testing.T) {
+	outputs := t.TempDir()
+	a := newArtifactStore(outputs, "example.com/foo_test", "TestFoo/sub test")
+	wantDir := filepath.Join(outputs, "example.com", "foo_test", "TestFoo", "sub_test")
+	if got := a.Dir(); got != wantDir {
+		t.Fatalf("Dir() got %q, want %q", got, wantDir)
+	}
+
+	writes := []func() (string, error){
+		func() (string, error) { return a.WriteJSON("state", map[string]int{"mtu": 1500}) },
+		func() (string, error) { return a.WriteJSON("state", json.RawMessage(`{"mtu":1514}`)) },
+		func() (string, error) { return a.WriteTextproto("value", wrapperspb.String("x")) },
+		func() (string, error) { return a.WritePCAP("port1", []byte{0xd4, 0xc3, 0xb2, 0xa1}) },
+		func() (string, error) { return a.WriteCSV("flows", [][]string{{"flow", "loss"}, {"v4", "0"}}) },
+		func() (string, error) { return a.Gzip().WriteCSV("flows", [][]string{{"flow", "loss"}}) },
+	}
+	var files []string
+	for _, w := range writes {
+		path, err := w()
+		if err != nil {
+			t.Fatalf("Write got error: %v", err)
+		}
+		files = append(files, filepath.Base(path))
+	}
+	wantFiles := []string{"state.json", "state-1.json", "value.textproto", "port1.pcap", "flows.csv", "flows.csv.gz"}
+	if diff := cmp.Diff(wantFiles, files); diff != "" {
+		t.Errorf("Written files diff (-want +got):\n%s", diff)
+	}
+
+	content, err := os.ReadFile(filepath.Join(wantDir, manifestFile))
+	if err != nil {
+		t.Fatalf("Cannot read the manifest: %v", err)
+	}
+	var got []*ManifestEntry
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("Cannot parse the manifest: %v", err)
+	}
+	want := []*ManifestEntry{
+		{File: "state.json", Test: "TestFoo/sub test", Kind: ArtifactJSON},
+		{File: "state-1.json", Test: "TestFoo/sub test", Kind: ArtifactJSON},
+		{File: "value.textproto", Test: "TestFoo/sub test", Kind: ArtifactTextproto},
+		{File: "port1.pcap", Test: "TestFoo/sub test", Kind: ArtifactPCAP},
+		{File: "flows.csv", Test: "TestFoo/sub test", Kind: ArtifactCSV},
+		{File: "flows.csv.gz", Test: "TestFoo/sub test", Kind: ArtifactCSV, Gzip: true},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(ManifestEntry{}, "Time")); diff != "" {
+		t.Errorf("Manifest diff (-want +got):\n%s", diff)
+	}
+
+	f, err := os.Open(filepath.Join(wantDir, "flows.csv.gz"))
+	if err != nil {
+		t.Fatalf("Cannot open the gzip artifact: %v", err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("Cannot read the gzip artifact: %v", err)
+	}
+	csv, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("Cannot read the gzip artifact: %v", err)
+	}
+	if got, want := string(csv), "flow,loss\n"; got != want {
+		t.Errorf("Gzip artifact got %q, want %q", got, want)
+	}
+}
+
+func TestArtifactStore_NoOutputsDir(t *testing.T) {
+	a := newArtifactStore("", "", t.Name())
+	path, err := a.WriteJSON("state", "{}")
+	if err != nil {
+		t.Errorf("WriteJSON got error: %v", err)
+	}
+	if path != "" {
+		t.Errorf("WriteJSON got path %q, want none", path)
+	}
+}
+
+func TestArtifactStore_Packages(t *testing.T) {
+	outputs := t.TempDir()
+	for _, pkg := range []string{"feature/foo/ate_tests/foo_test", "feature/foo/otg_tests/foo_test"} {
+		if _, err := newArtifactStore(outputs, pkg, "TestFoo").Write("log", ArtifactText, []byte("x")); err != nil {
+			t.Fatalf("Write got error: %v", err)
+		}
+		if got := readManifest(filepath.Join(outputs, pkg, "TestFoo")); len(got) != 1 {
+			t.Errorf("Manifest of %s got %d entries, want 1", pkg, len(got))
+		}
+	}
+}
+
+func TestPackagePath(t *testing.T) {
+	tests := []struct {
+		function, module, want string
+	}{
+		{"github.com/openconfig/featureprofiles/feature/foo_test.TestMain", "github.com/openconfig/featureprofiles", "feature/foo_test"},
+		{"github.com/openconfig/featureprofiles/feature/foo_test.TestMain.func1", "github.com/openconfig/featureprofiles", "feature/foo_test"},
+		{"example.com/foo.TestMain", "", "example.com/foo"},
+	}
+	for _, tt := range tests {
+		if got := packagePath(tt.function, tt.module); got != tt.want {
+			t.Errorf("packagePath(%q, %q) got %q, want %q", tt.function, tt.module, got, tt.want)
+		}
+	}
+}
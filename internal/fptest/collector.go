@@ -0,0 +1,326 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/openconfig/ygnmi/ygnmi"
+)
+
+// Category is the kind of a failure found by a Collector.
+type Category string
+
+// Categories of failures.
+const (
+	// MissingPath is a value which the device does not report.
+	MissingPath Category = "missing path"
+	// WrongValue is a check of the test which failed.
+	WrongValue Category = "wrong value"
+	// RPCError is an RPC which the device failed.
+	RPCError Category = "RPC error"
+	// OtherFailure is any other fatal failure.
+	OtherFailure Category = "other"
+)
+
+// categories are in the order of the summary.
+var categories = []Category{MissingPath, RPCError, WrongValue, OtherFailure}
+
+// Failure is a failure of a check run by a Collector.
+type Failure struct {
+	Check    string   `json:"check"`
+	Category Category `json:"category"`
+	Message  string   `json:"message"`
+	Fatal    bool     `json:"fatal,omitempty"`
+}
+
+// CollectorSummary is the summary of the checks run by a Collector.
+type CollectorSummary struct {
+	Test       string           `json:"test"`
+	Checks     int              `json:"checks"`
+	Failed     int              `json:"failed"`
+	ByCategory map[Category]int `json:"by_category,omitempty"`
+	Failures   []*Failure       `json:"failures,omitempty"`
+}
+
+// Collector runs many checks of a test without stopping at the first fatal
+// failure, e.g. when a gnmi.Get finds no value.  The failures are reported
+// together as one summary table when the test completes, and the summary is
+// written as the "collector" JSON artifact of the test.
+//
+//	c := fptest.NewCollector(t)
+//	for _, name := range components {
+//		c.Run(name+" serial-no", func(t testing.TB) {
+//			if got := gnmi.Get(t, dut, gnmi.OC().Component(name).SerialNo().State()); got == "" {
+//				t.Errorf("%s serial-no is empty", name)
+//			}
+//		})
+//	}
+type Collector struct {
+	t testing.TB
+
+	mu       sync.Mutex
+	checks   int
+	failures []*Failure
+	reported bool
+}
+
+// NewCollector returns a collector which reports its failures when the test
+// completes.
+func NewCollector(t testing.TB) *Collector {
+	c := &Collector{t: t}
+	t.Cleanup(c.Report)
+	return c
+}
+
+// Run runs a check, capturing its errors and any fatal failure.  It returns true
+// if the check passed.  The check may be run concurrently with other checks.
+//
+// The check runs on a goroutine of its own, which a fatal failure of the check
+// ends as it would end the goroutine of a *testing.T.  A fatal failure on a
+// goroutine started by the check ends only that goroutine.
+func (c *Collector) Run(check string, f func(t testing.TB)) bool {
+	ct := &collectT{TB: c.t}
+	done := make(chan interface{}, 1)
+	go func() {
+		var returned bool
+		defer func() {
+			var r interface{}
+			if !returned {
+				r = recover() // nil if the check was ended by runtime.Goexit.
+			}
+			done <- r
+		}()
+		f(ct)
+		returned = true
+	}()
+	if r := <-done; r != nil {
+		panic(r)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	c.checks++
+	for _, msg := range ct.errs {
+		c.failures = append(c.failures, &Failure{
+			Check:    check,
+			Category: classify(msg, false),
+			Message:  msg,
+		})
+	}
+	if ct.fatal != nil {
+		c.failures = append(c.failures, &Failure{
+			Check:    check,
+			Category: classify(*ct.fatal, true),
+			Message:  *ct.fatal,
+			Fatal:    true,
+		})
+	}
+	return len(ct.errs) == 0 && ct.fatal == nil
+}
+
+// classify returns the category of a failure message.
+func classify(msg string, fatal bool) Category {
+	switch {
+	case strings.Contains(msg, ygnmi.ErrNotPresent.Error()):
+		return MissingPath
+	case strings.Contains(msg, "rpc error: code ="):
+		return RPCError
+	case fatal:
+		return OtherFailure
+	default:
+		return WrongValue
+	}
+}
+
+// Summary returns the summary of the checks run so far.
+func (c *Collector) Summary() *CollectorSummary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := &CollectorSummary{
+		Test:     c.t.Name(),
+		Checks:   c.checks,
+		Failures: append([]*Failure(nil), c.failures...),
+	}
+	failed := make(map[string]bool)
+	for _, f := range c.failures {
+		if s.ByCategory == nil {
+			s.ByCategory = make(map[Category]int)
+		}
+		s.ByCategory[f.Category]++
+		failed[f.Check] = true
+	}
+	s.Failed = len(failed)
+	sort.SliceStable(s.Failures, func(i, j int) bool {
+		return categoryIndex(s.Failures[i].Category) < categoryIndex(s.Failures[j].Category)
+	})
+	return s
+}
+
+func categoryIndex(c Category) int {
+	for i, cat := range categories {
+		if cat == c {
+			return i
+		}
+	}
+	return len(categories)
+}
+
+// String renders the summary as a table with one row per failure, grouped by
+// category.
+func (s *CollectorSummary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d checks failed", s.Failed, s.Checks)
+	for _, cat := range categories {
+		if n := s.ByCategory[cat]; n > 0 {
+			fmt.Fprintf(&b, ", %d %s", n, cat)
+		}
+	}
+	if len(s.Failures) == 0 {
+		return b.String()
+	}
+	b.WriteString(":\n")
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "CATEGORY\tCHECK\tMESSAGE")
+	for _, f := range s.Failures {
+		msg := strings.TrimSpace(f.Message)
+		if i := strings.IndexByte(msg, '\n'); i >= 0 {
+			msg = msg[:i] + " ..."
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Category, f.Check, msg)
+	}
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Report logs the summary, fails the test with the summary table if any check
+// failed, and writes the summary artifact.  It is called when the test
+// completes; calling it again has no effect.
+func (c *Collector) Report() {
+	c.t.Helper()
+	c.mu.Lock()
+	reported := c.reported
+	c.reported = true
+	c.mu.Unlock()
+	if reported {
+		return
+	}
+
+	s := c.Summary()
+	if _, err := Artifacts(c.t).WriteJSON("collector", s); err != nil {
+		c.t.Logf("Cannot write the collector summary: %v", err)
+	}
+	if len(s.Failures) > 0 {
+		c.t.Error(s)
+		return
+	}
+	c.t.Log(s)
+}
+
+// collectT captures the errors and the fatal failure of a check.  Other methods
+// are those of the real test.
+type collectT struct {
+	testing.TB
+
+	mu      sync.Mutex
+	errs    []string
+	fatal   *string
+	skipped bool
+}
+
+func (ct *collectT) Helper() {}
+
+func (ct *collectT) Error(args ...interface{}) {
+	ct.addError(fmt.Sprintln(args...))
+}
+
+func (ct *collectT) Errorf(format string, args ...interface{}) {
+	ct.addError(fmt.Sprintf(format, args...))
+}
+
+func (ct *collectT) Fail() {
+	ct.addError("check marked as failed")
+}
+
+func (ct *collectT) Failed() bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return len(ct.errs) > 0 || ct.fatal != nil
+}
+
+func (ct *collectT) Skipped() bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return ct.skipped
+}
+
+func (ct *collectT) addError(msg string) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.errs = append(ct.errs, strings.TrimSuffix(msg, "\n"))
+}
+
+func (ct *collectT) Fatal(args ...interface{}) {
+	ct.stop(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (ct *collectT) Fatalf(format string, args ...interface{}) {
+	ct.stop(fmt.Sprintf(format, args...))
+}
+
+func (ct *collectT) FailNow() {
+	ct.stop("check stopped")
+}
+
+// stop records the fatal failure of the check and stops it.
+func (ct *collectT) stop(msg string) {
+	ct.mu.Lock()
+	if ct.fatal == nil {
+		ct.fatal = &msg
+	}
+	ct.mu.Unlock()
+	ct.exit()
+}
+
+func (ct *collectT) Skip(args ...interface{}) {
+	ct.Log(args...)
+	ct.SkipNow()
+}
+
+func (ct *collectT) Skipf(format string, args ...interface{}) {
+	ct.Logf(format, args...)
+	ct.SkipNow()
+}
+
+func (ct *collectT) SkipNow() {
+	ct.mu.Lock()
+	ct.skipped = true
+	ct.mu.Unlock()
+	ct.exit()
+}
+
+// exit ends the calling goroutine, which is the goroutine of the check or one
+// started by it.
+func (ct *collectT) exit() {
+	runtime.Goexit()
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCollector(t *testing.T) {
+	c := &Collector{t: t}
+	checks := []struct {
+		name string
+		f    func(t testing.TB)
+		want bool
+	}{{
+		name: "pass",
+		f:    func(t testing.TB) { t.Log("all good") },
+		want: true,
+	}, {
+		name: "missing",
+		f: func(t testing.TB) {
+			t.Fatalf("Get(t) on dut at /components/component[name=FAN1]/state/serial-no: %v", ygnmi.ErrNotPresent)
+		},
+	}, {
+		name: "rpc",
+		f: func(t testing.TB) {
+			t.Fatalf("Lookup(t) on dut at /system: %v", status.Error(codes.Unavailable, "connection refused"))
+		},
+	}, {
+		name: "values",
+		f: func(t testing.TB) {
+			t.Errorf("serial-no: got %q, want non-empty", "")
+			t.Error("oper-status: got DISABLED, want ACTIVE")
+			t.Fatal("giving up")
+			t.Error("not reached")
+		},
+	}, {
+		name: "skip",
+		f:    func(t testing.TB) { t.Skip("not supported") },
+		want: true,
+	}}
+	for _, check := range checks {
+		if got := c.Run(check.name, check.f); got != check.want {
+			t.Errorf("Run(%q) got %v, want %v", check.name, got, check.want)
+		}
+	}
+
+	got := c.Summary()
+	want := &CollectorSummary{
+		Test:   t.Name(),
+		Checks: 5,
+		Failed: 3,
+		ByCategory: map[Category]int{
+			MissingPath:  1,
+			RPCError:     1,
+			WrongValue:   2,
+			OtherFailure: 1,
+		},
+		Failures: []*Failure{{
+			Check:    "missing",
+			Category: MissingPath,
+			Message:  "Get(t) on dut at /components/component[name=FAN1]/state/serial-no: value not present",
+			Fatal:    true,
+		}, {
+			Check:    "rpc",
+			Category: RPCError,
+			Message:  "Lookup(t) on dut at /system: rpc error: code = Unavailable desc = connection refused",
+			Fatal:    true,
+		}, {
+			Check:    "values",
+			Category: WrongValue,
+			Message:  `serial-no: got "", want non-empty`,
+		}, {
+			Check:    "values",
+			Category: WrongValue,
+			Message:  "oper-status: got DISABLED, want ACTIVE",
+		}, {
+			Check:    "values",
+			Category: OtherFailure,
+			Message:  "giving up",
+			Fatal:    true,
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Summary() diff (-want +got):\n%s", diff)
+	}
+
+	table := got.String()
+	for _, s := range []string{
+		"3 of 5 checks failed, 1 missing path, 1 RPC error, 2 wrong value, 1 other:",
+		"CATEGORY",
+		"missing path  missing",
+		"RPC error     rpc",
+	} {
+		if !strings.Contains(table, s) {
+			t.Errorf("Summary().String() got:\n%s\nwant it to contain %q", table, s)
+		}
+	}
+}
+
+func TestCollector_Goroutine(t *testing.T) {
+	c := &Collector{t: t}
+	var failed bool
+	ok := c.Run("goroutine", func(t testing.TB) {
+		// A helper which fails fatally, e.g. gnmi.Get, called on a goroutine.
+		fatal := func(t testing.TB) {
+			t.Fatal("fatal on a goroutine")
+			t.Error("not reached")
+		}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			fatal(t)
+		}()
+		<-done
+		failed = t.Failed()
+	})
+	if ok {
+		t.Errorf("Run got true, want false")
+	}
+	if !failed {
+		t.Errorf("Failed() after a fatal failure got false, want true")
+	}
+	want := []*Failure{{
+		Check:    "goroutine",
+		Category: OtherFailure,
+		Message:  "fatal on a goroutine",
+		Fatal:    true,
+	}}
+	if diff := cmp.Diff(want, c.Summary().Failures); diff != "" {
+		t.Errorf("Summary().Failures diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestCollector_Panic(t *testing.T) {
+	c := &Collector{t: t}
+	defer func() {
+		if r := recover(); r != "check panic" {
+			t.Errorf("Run panicked with %v, want the panic of the check", r)
+		}
+	}()
+	c.Run("panic", func(t testing.TB) {
+		panic("check panic")
+	})
+	t.Errorf("Run returned, want the panic of the check")
+}
+
+func TestCollector_Report(t *testing.T) {
+	dir := t.TempDir()
+	defer func(old string) { *outputsDir = old }(*outputsDir)
+	*outputsDir = dir
+
+	ft := &fakeReportT{TB: t}
+	c := &Collector{t: ft}
+	c.Run("ok", func(t testing.TB) {})
+	c.Run("bad", func(t testing.TB) { t.Errorf("bad value") })
+	c.Report()
+	c.Report()
+
+	if len(ft.errs) != 1 {
+		t.Fatalf("Report() reported %d errors, want 1: %v", len(ft.errs), ft.errs)
+	}
+	if !strings.Contains(ft.errs[0], "1 of 2 checks failed") {
+		t.Errorf("Report() error got %q, want the summary", ft.errs[0])
+	}
+
+	content, err := os.ReadFile(filepath.Join(Artifacts(t).Dir(), "collector.json"))
+	if err != nil {
+		t.Fatalf("Cannot read the collector artifact: %v", err)
+	}
+	var s CollectorSummary
+	if err := json.Unmarshal(content, &s); err != nil {
+		t.Fatalf("Cannot unmarshal the collector artifact: %v", err)
+	}
+	if s.Checks != 2 || s.Failed != 1 || s.ByCategory[WrongValue] != 1 {
+		t.Errorf("Collector artifact got %+v, want 2 checks with 1 wrong value", s)
+	}
+}
+
+// fakeReportT records the errors of a test instead of failing it.
+type fakeReportT struct {
+	testing.TB
+	errs []string
+}
+
+func (ft *fakeReportT) Error(args ...interface{}) {
+	ft.errs = append(ft.errs, fmt.Sprint(args...))
+}
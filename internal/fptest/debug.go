@@ -0,0 +1,99 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"context"
+	"flag"
+	"log"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra/binding"
+
+	fpbinding "github.com/openconfig/featureprofiles/topologies/binding"
+)
+
+// debugDir is the directory of the debug data in -outputs_dir, and in the
+// artifact directory of a test.
+const debugDir = "debug"
+
+var (
+	debugOnRelease = flag.Bool("debug_on_release", false,
+		"collect the debug data of the DUTs of a static binding into -outputs_dir when the reservation is released after a test failure")
+
+	// debugTimeout bounds the collection of the debug data.
+	debugTimeout = 5 * time.Minute
+
+	// testFailed is set when a test watched by watchFailure fails.
+	testFailed atomic.Bool
+)
+
+// watchFailure records the failure of the test for the collection of the
+// debug data when the reservation is released.
+func watchFailure(t testing.TB) {
+	t.Cleanup(func() {
+		if t.Failed() {
+			testFailed.Store(true)
+		}
+	})
+}
+
+// CollectDebugOnFailure collects the debug data of the DUTs when the test
+// fails, into the debug directory of its artifacts.  The data is configured by
+// the debug message of each device of a static binding; see binding.proto.
+func CollectDebugOnFailure(t testing.TB) {
+	watchFailure(t)
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		dir := Artifacts(t).Dir()
+		if dir == "" {
+			t.Logf("Debug data is not collected without -outputs_dir.")
+			return
+		}
+		if err := collectDebug(filepath.Join(dir, debugDir)); err != nil {
+			t.Logf("Could not collect all the debug data: %v", err)
+		}
+	})
+}
+
+// collectDebug collects the debug data of the DUTs into the directory.
+var collectDebug = func(dir string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), debugTimeout)
+	defer cancel()
+	return fpbinding.CollectDebug(ctx, dir)
+}
+
+// debugBind collects the debug data of the DUTs when the reservation is
+// released, if -debug_on_release is set and a test registered by
+// CollectDebugOnFailure or AuditSets failed.
+type debugBind struct {
+	binding.Binding
+}
+
+func (b *debugBind) Release(ctx context.Context) error {
+	if *debugOnRelease && testFailed.Load() {
+		if *outputsDir == "" {
+			log.Printf("Debug data is not collected without -outputs_dir.")
+		} else if err := collectDebug(filepath.Join(*outputsDir, debugDir)); err != nil {
+			log.Printf("Could not collect all the debug data: %v", err)
+		}
+	}
+	return b.Binding.Release(ctx)
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openconfig/ondatra/binding"
+)
+
+// fakeReleaseBind records whether the reservation was released.
+type fakeReleaseBind struct {
+	binding.Binding
+	released bool
+}
+
+func (b *fakeReleaseBind) Release(context.Context) error {
+	b.released = true
+	return nil
+}
+
+func TestDebugBind_Release(t *testing.T) {
+	defer func(old string) { *outputsDir = old }(*outputsDir)
+	*outputsDir = t.TempDir()
+	defer func(old bool) { *debugOnRelease = old }(*debugOnRelease)
+	*debugOnRelease = true
+	defer func(old func(string) error) { collectDebug = old }(collectDebug)
+	var collected bool
+	collectDebug = func(string) error {
+		collected = true
+		return nil
+	}
+	defer testFailed.Store(testFailed.Load())
+
+	for _, failed := range []bool{false, true} {
+		testFailed.Store(failed)
+		collected = false
+		fb := &fakeReleaseBind{}
+		b := &debugBind{Binding: fb}
+		if err := b.Release(context.Background()); err != nil {
+			t.Fatalf("Release got error: %v", err)
+		}
+		if !fb.released {
+			t.Errorf("Release did not release the wrapped binding")
+		}
+		if collected != failed {
+			t.Errorf("Release after a failure %v collected the debug data %v, want %v", failed, collected, failed)
+		}
+	}
+}
@@ -110,7 +110,7 @@ var _ LoggableQuery = ygnmi.AnyQuery[string](nil)
 
 // LogQuery logs a ygot GoStruct at path as either config or telemetry,
 // depending on the query.  It also writes a copy to a *.json file in
-// the directory specified by the -outputs_dir flag.
+// the artifact directory of the test, see Artifacts.
 func LogQuery(t testing.TB, what string, query LoggableQuery, obj ygot.ValidatedGoStruct) {
 	t.Helper()
 	logQuery(t, what, query, obj, true)
@@ -143,7 +143,7 @@ func logQuery(t testing.TB, what string, query LoggableQuery, obj ygot.Validated
 	if shouldLog {
 		t.Logf("%s:\n%s", header, text)
 	}
-	if err := WriteOutput(t.Name()+" "+header, ".json", text); err != nil {
+	if _, err := Artifacts(t).Write(header, ArtifactJSON, []byte(text)); err != nil {
 		t.Logf("Could not write test output: %v", err)
 	}
 }
@@ -25,6 +25,16 @@ import (
 )
 
 // AssignToNetworkInstance attaches a subinterface to a network instance.
+//
+// Deprecated: Use the networkinstance package, which also applies the network
+// instance deviations of the DUT, e.g.
+//
+//	networkinstance.Default(dut).WithInterface(p1.Name(), 0).Push(t, dut)
+//
+// The remaining callers attach ports to the default network instance under the
+// ExplicitInterfaceInDefaultVRF deviation.  They are moved to the builder one
+// test at a time, together with their ATE and OTG twins, as each migration is
+// validated on hardware, and this function is removed once none is left.
 func AssignToNetworkInstance(t testing.TB, d *ondatra.DUTDevice, i string, ni string, si uint32) {
 	t.Helper()
 	if ni == "" {
@@ -0,0 +1,293 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// defaultPortTimeout is how long ConfigurePort waits for the port to be up.
+const defaultPortTimeout = 2 * time.Minute
+
+// speedByGbps maps a port speed in Gbps to OpenConfig.  The ondatra.Speed
+// of a port is its speed in Gbps.
+var speedByGbps = map[int]oc.E_IfEthernet_ETHERNET_SPEED{
+	1:   oc.IfEthernet_ETHERNET_SPEED_SPEED_1GB,
+	5:   oc.IfEthernet_ETHERNET_SPEED_SPEED_5GB,
+	10:  oc.IfEthernet_ETHERNET_SPEED_SPEED_10GB,
+	25:  oc.IfEthernet_ETHERNET_SPEED_SPEED_25GB,
+	40:  oc.IfEthernet_ETHERNET_SPEED_SPEED_40GB,
+	50:  oc.IfEthernet_ETHERNET_SPEED_SPEED_50GB,
+	100: oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB,
+	200: oc.IfEthernet_ETHERNET_SPEED_SPEED_200GB,
+	400: oc.IfEthernet_ETHERNET_SPEED_SPEED_400GB,
+	600: oc.IfEthernet_ETHERNET_SPEED_SPEED_600GB,
+	800: oc.IfEthernet_ETHERNET_SPEED_SPEED_800GB,
+}
+
+// pmdRE matches the PMD names, e.g. PMD_100GBASE_LR4 or PMD_4X10GBASE_SR,
+// capturing the number of lanes of a breakout PMD and the speed per lane.
+var pmdRE = regexp.MustCompile(`^PMD_(?:(\d+)X)?(\d+)G`)
+
+// portSpeed returns the OpenConfig speed of a port from its ondatra speed
+// and PMD.  A breakout PMD such as 4X10GBASE-SR gives the number of
+// breakouts as lanes, and the speed of each breakout, which the ondatra
+// speed must agree with: it is either the speed of each breakout or of the
+// whole port.  Otherwise lanes is 1, and the ondatra speed takes precedence
+// over the speed of the PMD.
+func portSpeed(speed ondatra.Speed, pmd ondatra.PMD) (s oc.E_IfEthernet_ETHERNET_SPEED, lanes uint8, ok bool) {
+	lanes = 1
+	var pmdGbps int
+	if m := pmdRE.FindStringSubmatch(pmd.String()); m != nil {
+		pmdGbps, _ = strconv.Atoi(m[2])
+		if m[1] != "" {
+			n, err := strconv.Atoi(m[1])
+			if err != nil || n < 1 || n > 255 {
+				return 0, 0, false
+			}
+			lanes = uint8(n)
+		}
+	}
+	if lanes == 1 {
+		if s, ok := speedByGbps[int(speed)]; ok {
+			return s, 1, true
+		}
+		s, ok = speedByGbps[pmdGbps]
+		if !ok {
+			return 0, 0, false
+		}
+		return s, 1, true
+	}
+	s, ok = speedByGbps[pmdGbps]
+	if !ok {
+		return 0, 0, false
+	}
+	if gbps := int(speed); gbps != 0 && gbps != pmdGbps && gbps != pmdGbps*int(lanes) {
+		return 0, 0, false
+	}
+	return s, lanes, true
+}
+
+// Breakout is the breakout mode of a physical port.
+type Breakout struct {
+	// NumBreakouts is the number of interfaces the port is broken out to.
+	NumBreakouts uint8
+	// Speed is the speed of each breakout interface.
+	Speed oc.E_IfEthernet_ETHERNET_SPEED
+	// NumPhysicalChannels is the number of lanes of each breakout
+	// interface, or 0 to leave it to the device.
+	NumPhysicalChannels uint8
+}
+
+// PortSettings is the configuration of a port applied by ConfigurePort.
+// The zero value leaves everything to the device except the port speed,
+// see Speed.
+type PortSettings struct {
+	// Speed of the port.  If unset, the speed is derived from the speed or
+	// the PMD of the ondatra port, and only configured with
+	// deviation_explicit_port_speed; otherwise the device should negotiate
+	// the highest speed available.
+	Speed oc.E_IfEthernet_ETHERNET_SPEED
+	// Duplex mode of the port, or unset to leave it to the device.
+	Duplex oc.E_Ethernet_DuplexMode
+	// AutoNegotiate enables or disables auto-negotiation, if set.
+	AutoNegotiate *bool
+	// FEC is the forward error correction mode, or unset to leave it to
+	// the device.
+	FEC oc.E_IfEthernet_INTERFACE_FEC
+	// Breakout configures the breakout mode of the physical port of the
+	// interface.  If unset, it is derived from a breakout PMD of the ondatra
+	// port, e.g. 4X10GBASE-SR, and the port is not broken out otherwise.
+	Breakout *Breakout
+	// Timeout is how long to wait for the port to be up with the expected
+	// speed, by default 2 minutes.
+	Timeout time.Duration
+}
+
+// ConfigurePort configures the breakout mode, speed, duplex mode,
+// auto-negotiation and FEC of a DUT port according to the settings, then
+// waits for its oper-status to be UP with the expected speed.  When the port
+// is broken out, the settings apply to each breakout interface, and it waits
+// for each of them instead.
+//
+//	fptest.ConfigurePort(t, dut.Port(t, "port1"), &fptest.PortSettings{
+//	  FEC: oc.IfEthernet_INTERFACE_FEC_FEC_RS544,
+//	})
+func ConfigurePort(t testing.TB, p *ondatra.Port, s *PortSettings) {
+	t.Helper()
+	if s == nil {
+		s = &PortSettings{}
+	}
+	dev := p.Device()
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = defaultPortTimeout
+	}
+
+	derived, lanes, ok := portSpeed(p.Speed(), p.PMD())
+	breakout := s.Breakout
+	if breakout == nil && ok && lanes > 1 {
+		breakout = &Breakout{NumBreakouts: lanes, Speed: derived}
+	}
+	intfs := []string{p.Name()}
+	if breakout != nil {
+		component := configureBreakout(t, p, breakout)
+		intfs = breakoutInterfaces(t, dev, component, int(breakout.NumBreakouts), timeout)
+		derived, ok = breakout.Speed, true
+	}
+
+	// speed is configured and want is waited for.
+	speed, want := s.Speed, s.Speed
+	if speed == oc.IfEthernet_ETHERNET_SPEED_UNSET {
+		switch {
+		case !ok:
+			t.Logf("Port %v has no known speed (speed %v, PMD %v), leaving it to the device", p.Name(), p.Speed(), p.PMD())
+		case deviations.For(dev).ExplicitPortSpeed():
+			speed, want = derived, derived
+		default:
+			want = derived
+		}
+	}
+
+	eth := &oc.Interface_Ethernet{
+		PortSpeed:     speed,
+		DuplexMode:    s.Duplex,
+		AutoNegotiate: s.AutoNegotiate,
+		FecMode:       s.FEC,
+	}
+	if !isEmptyStruct(eth) {
+		for _, name := range intfs {
+			t.Logf("Configuring port %v: speed %v, duplex %v, auto-negotiate %v, FEC %v", name, speed, s.Duplex, s.AutoNegotiate, s.FEC)
+			gnmi.Update(t, dev, gnmi.OC().Interface(name).Ethernet().Config(), eth)
+		}
+	}
+
+	for _, name := range intfs {
+		gnmi.Await(t, dev, gnmi.OC().Interface(name).OperStatus().State(), timeout, oc.Interface_OperStatus_UP)
+		if want != oc.IfEthernet_ETHERNET_SPEED_UNSET {
+			waitPortSpeed(t, dev, name, want, timeout)
+		}
+	}
+}
+
+// isEmptyStruct reports whether no field of the struct is set.
+func isEmptyStruct(s ygot.GoStruct) bool {
+	b, err := ygot.Marshal7951(s)
+	return err == nil && string(b) == "{}"
+}
+
+// configureBreakout sets the breakout mode of the physical port of the
+// interface, and returns the name of the port component.  The port component
+// is the hardware-port of the interface; if the device does not report it, the
+// interface name is used instead.
+func configureBreakout(t testing.TB, p *ondatra.Port, b *Breakout) string {
+	t.Helper()
+	dev := p.Device()
+	component := p.Name()
+	if hw, ok := gnmi.Lookup(t, dev, gnmi.OC().Interface(p.Name()).HardwarePort().State()).Val(); ok && hw != "" {
+		component = hw
+	} else {
+		t.Logf("Port %v does not report its hardware-port, using it as the port component name", p.Name())
+	}
+
+	group := &oc.Component_Port_BreakoutMode_Group{
+		Index:         ygot.Uint8(0),
+		NumBreakouts:  ygot.Uint8(b.NumBreakouts),
+		BreakoutSpeed: b.Speed,
+	}
+	if b.NumPhysicalChannels != 0 {
+		group.NumPhysicalChannels = ygot.Uint8(b.NumPhysicalChannels)
+	}
+	mode := &oc.Component_Port_BreakoutMode{}
+	if err := mode.AppendGroup(group); err != nil {
+		t.Fatalf("Cannot build the breakout mode of %s: %v", component, err)
+	}
+	t.Logf("Configuring breakout of %s to %dx%v", component, b.NumBreakouts, b.Speed)
+	gnmi.Replace(t, dev, gnmi.OC().Component(component).Port().BreakoutMode().Config(), mode)
+	return component
+}
+
+// breakoutInterfaces waits for the n breakout interfaces of the port component,
+// i.e. the interfaces with the component as their hardware-port, and returns
+// their names in order.
+func breakoutInterfaces(t testing.TB, dev *ondatra.Device, component string, n int, timeout time.Duration) []string {
+	t.Helper()
+	found := make(map[string]bool)
+	_, ok := gnmi.WatchAll(t, dev, gnmi.OC().InterfaceAny().HardwarePort().State(), timeout, func(v *ygnmi.Value[string]) bool {
+		if hw, present := v.Val(); present && hw == component {
+			if name := interfaceName(v.Path); name != "" {
+				found[name] = true
+			}
+		}
+		return len(found) >= n
+	}).Await(t)
+	if !ok {
+		t.Fatalf("Port %s got %d breakout interfaces, want %d", component, len(found), n)
+	}
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// interfaceName returns the name of the interface in a path under
+// /interfaces/interface, or an empty string.
+func interfaceName(path *gpb.Path) string {
+	for _, e := range path.GetElem() {
+		if e.GetName() == "interface" {
+			return e.GetKey()["name"]
+		}
+	}
+	return ""
+}
+
+// waitPortSpeed waits for the interface to run at the speed.  It checks the
+// negotiated speed, or the port speed if the device does not report the
+// negotiated speed, e.g. when auto-negotiation is disabled.
+func waitPortSpeed(t testing.TB, dev *ondatra.Device, name string, speed oc.E_IfEthernet_ETHERNET_SPEED, timeout time.Duration) {
+	t.Helper()
+	q := gnmi.OC().Interface(name).Ethernet().State()
+	_, ok := gnmi.Watch(t, dev, q, timeout, func(v *ygnmi.Value[*oc.Interface_Ethernet]) bool {
+		eth, present := v.Val()
+		return present && ethernetSpeed(eth) == speed
+	}).Await(t)
+	if !ok {
+		eth, _ := gnmi.Lookup(t, dev, q).Val()
+		t.Fatalf("Port %v speed got %v, want %v", name, ethernetSpeed(eth), speed)
+	}
+}
+
+// ethernetSpeed returns the negotiated speed, or else the port speed.
+func ethernetSpeed(eth *oc.Interface_Ethernet) oc.E_IfEthernet_ETHERNET_SPEED {
+	if s := eth.GetNegotiatedPortSpeed(); s != oc.IfEthernet_ETHERNET_SPEED_UNSET && s != oc.IfEthernet_ETHERNET_SPEED_SPEED_UNKNOWN {
+		return s
+	}
+	return eth.GetPortSpeed()
+}
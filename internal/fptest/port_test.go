@@ -0,0 +1,117 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"testing"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestPortSpeed(t *testing.T) {
+	cases := []struct {
+		desc      string
+		speed     ondatra.Speed
+		pmd       ondatra.PMD
+		want      oc.E_IfEthernet_ETHERNET_SPEED
+		wantLanes uint8
+		wantOK    bool
+	}{
+		{desc: "speed", speed: ondatra.Speed400Gb, pmd: ondatra.PMD100GBASELR4, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_400GB, wantLanes: 1, wantOK: true},
+		{desc: "speed not in ondatra", speed: ondatra.Speed(25), want: oc.IfEthernet_ETHERNET_SPEED_SPEED_25GB, wantLanes: 1, wantOK: true},
+		{desc: "800G", speed: ondatra.Speed(800), want: oc.IfEthernet_ETHERNET_SPEED_SPEED_800GB, wantLanes: 1, wantOK: true},
+		{desc: "PMD", pmd: ondatra.PMD40GBASESR4, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_40GB, wantLanes: 1, wantOK: true},
+		{desc: "breakout PMD", pmd: ondatra.PMD4X10GBASELR, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_10GB, wantLanes: 4, wantOK: true},
+		{desc: "breakout PMD with lane speed", speed: ondatra.Speed10Gb, pmd: ondatra.PMD4X10GBASELR, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_10GB, wantLanes: 4, wantOK: true},
+		{desc: "breakout PMD with port speed", speed: ondatra.Speed(40), pmd: ondatra.PMD4X10GBASELR, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_10GB, wantLanes: 4, wantOK: true},
+		{desc: "breakout PMD with other speed", speed: ondatra.Speed100Gb, pmd: ondatra.PMD4X10GBASELR},
+		{desc: "AOC", pmd: ondatra.PMD100GAOC, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB, wantLanes: 1, wantOK: true},
+		{desc: "unknown speed", speed: ondatra.Speed(3)},
+		{desc: "unspecified"},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			got, lanes, ok := portSpeed(c.speed, c.pmd)
+			if got != c.want || lanes != c.wantLanes || ok != c.wantOK {
+				t.Errorf("portSpeed(%v, %v) got %v, %d, %v; want %v, %d, %v", c.speed, c.pmd, got, lanes, ok, c.want, c.wantLanes, c.wantOK)
+			}
+		})
+	}
+}
+
+func TestEthernetSpeed(t *testing.T) {
+	cases := []struct {
+		desc string
+		eth  *oc.Interface_Ethernet
+		want oc.E_IfEthernet_ETHERNET_SPEED
+	}{
+		{desc: "nil", want: oc.IfEthernet_ETHERNET_SPEED_UNSET},
+		{desc: "negotiated", eth: &oc.Interface_Ethernet{
+			PortSpeed:           oc.IfEthernet_ETHERNET_SPEED_SPEED_400GB,
+			NegotiatedPortSpeed: oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB,
+		}, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB},
+		{desc: "not negotiated", eth: &oc.Interface_Ethernet{
+			PortSpeed:           oc.IfEthernet_ETHERNET_SPEED_SPEED_400GB,
+			NegotiatedPortSpeed: oc.IfEthernet_ETHERNET_SPEED_SPEED_UNKNOWN,
+		}, want: oc.IfEthernet_ETHERNET_SPEED_SPEED_400GB},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := ethernetSpeed(c.eth); got != c.want {
+				t.Errorf("ethernetSpeed got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsEmptyStruct(t *testing.T) {
+	if !isEmptyStruct(&oc.Interface_Ethernet{}) {
+		t.Errorf("isEmptyStruct of an empty struct got false")
+	}
+	if isEmptyStruct(&oc.Interface_Ethernet{AutoNegotiate: ygot.Bool(false)}) {
+		t.Errorf("isEmptyStruct with auto-negotiate false got true")
+	}
+}
+
+func TestInterfaceName(t *testing.T) {
+	cases := []struct {
+		desc string
+		path *gpb.Path
+		want string
+	}{
+		{desc: "interface", path: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "Ethernet1/2"}},
+			{Name: "state"},
+			{Name: "hardware-port"},
+		}}, want: "Ethernet1/2"},
+		{desc: "component", path: &gpb.Path{Elem: []*gpb.PathElem{
+			{Name: "components"},
+			{Name: "component", Key: map[string]string{"name": "Ethernet1"}},
+		}}},
+		{desc: "nil"},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := interfaceName(c.path); got != c.want {
+				t.Errorf("interfaceName got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
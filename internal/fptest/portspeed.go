@@ -28,23 +28,15 @@ import (
 
 	"github.com/openconfig/ondatra"
 	"github.com/openconfig/ondatra/gnmi"
-	"github.com/openconfig/ondatra/gnmi/oc"
 )
 
-var portSpeed = map[ondatra.Speed]oc.E_IfEthernet_ETHERNET_SPEED{
-	ondatra.Speed1Gb:   oc.IfEthernet_ETHERNET_SPEED_SPEED_1GB,
-	ondatra.Speed5Gb:   oc.IfEthernet_ETHERNET_SPEED_SPEED_5GB,
-	ondatra.Speed10Gb:  oc.IfEthernet_ETHERNET_SPEED_SPEED_10GB,
-	ondatra.Speed100Gb: oc.IfEthernet_ETHERNET_SPEED_SPEED_100GB,
-	ondatra.Speed400Gb: oc.IfEthernet_ETHERNET_SPEED_SPEED_400GB,
-}
-
 // SetPortSpeed sets the DUT config for the interface port-speed according
-// to ondatra.Prot.Speed()
+// to ondatra.Port.Speed(), or else the speed of its PMD.  See ConfigurePort
+// to also configure breakout, duplex, auto-negotiation and FEC.
 func SetPortSpeed(t *testing.T, p *ondatra.Port) {
-	speed, ok := portSpeed[p.Speed()]
+	speed, _, ok := portSpeed(p.Speed(), p.PMD())
 	if !ok {
-		// Port speed is unspecified or unrecognized. Explicit config not performed
+		t.Logf("Port %v has no known speed (speed %v, PMD %v), port-speed not configured", p.Name(), p.Speed(), p.PMD())
 		return
 	}
 	t.Logf("Configuring %v port-speed to %v", p.Name(), speed)
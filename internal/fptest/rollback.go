@@ -0,0 +1,133 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygnmi/ygnmi"
+	"github.com/openconfig/ygot/ygot"
+
+	fpbinding "github.com/openconfig/featureprofiles/topologies/binding"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// maxLoggedChanges limits the config changes logged by the rollback;
+// all of them are written to the config_rollback artifact.
+const maxLoggedChanges = 20
+
+// WithConfigRollback takes a snapshot of the OpenConfig configuration of
+// the DUT, and restores it with a gNMI replace when the test ends.
+//
+//	func TestFoo(t *testing.T) {
+//	  dut := ondatra.DUT(t, "dut")
+//	  fptest.WithConfigRollback(t, dut)
+//	  ...
+//	}
+//
+// The config changes made by the test are logged before the rollback.
+// If the DUT rejects the replace, the DUT is reset with the reset config
+// of the static binding instead, see binding.ResetDUT.
+func WithConfigRollback(t testing.TB, dut *ondatra.DUTDevice) {
+	t.Helper()
+	before := getRootConfig(t, dut)
+	t.Cleanup(func() {
+		rollbackConfig(t, dut, before)
+	})
+}
+
+// getRootConfig fetches the config root of the DUT with a gNMI Get.
+func getRootConfig(t testing.TB, dut *ondatra.DUTDevice) *oc.Root {
+	t.Helper()
+	return gnmi.GetConfig(t, dut.GNMIOpts().WithYGNMIOpts(ygnmi.WithUseGet()), gnmi.OC().Config())
+}
+
+func rollbackConfig(t testing.TB, dut *ondatra.DUTDevice, before *oc.Root) {
+	t.Helper()
+	var after *oc.Root
+	if !NonFatal(t, func(t testing.TB) { after = getRootConfig(t, dut) }) {
+		t.Logf("Could not fetch the config of %s, rolling back anyway", dut.Name())
+	} else {
+		changes, err := configChanges(before, after)
+		if err != nil {
+			t.Logf("Could not compare the config of %s, rolling back anyway: %v", dut.Name(), err)
+		} else if len(changes) == 0 {
+			t.Logf("Config of %s is unchanged, no rollback needed", dut.Name())
+			return
+		} else {
+			logConfigChanges(t, dut.Name(), changes)
+		}
+	}
+
+	if NonFatal(t, func(t testing.TB) { gnmi.Replace(t, dut, gnmi.OC().Config(), before) }) {
+		t.Logf("Config of %s rolled back", dut.Name())
+		return
+	}
+	t.Logf("Config replace was rejected by %s, resetting it with the binding reset config", dut.Name())
+	if err := fpbinding.ResetDUT(context.Background(), dut.Name()); err != nil {
+		t.Errorf("Could not reset %s: %v", dut.Name(), err)
+	}
+}
+
+// configChanges lists the config paths changed between two snapshots,
+// prefixed with "-" if deleted or "+" if added or modified.
+func configChanges(before, after *oc.Root) ([]string, error) {
+	diff, err := ygot.Diff(before, after, &ygot.DiffPathOpt{PreferShadowPath: true})
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for _, p := range diff.GetDelete() {
+		changes = append(changes, "- "+pathString(p))
+	}
+	for _, u := range diff.GetUpdate() {
+		changes = append(changes, fmt.Sprintf("+ %s: %v", pathString(u.GetPath()), u.GetVal()))
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i][2:] < changes[j][2:]
+	})
+	return changes, nil
+}
+
+func pathString(p *gpb.Path) string {
+	s, err := ygot.PathToString(p)
+	if err != nil {
+		return fmt.Sprintf("<unstringable path: %v>", err)
+	}
+	return s
+}
+
+func logConfigChanges(t testing.TB, name string, changes []string) {
+	t.Helper()
+	logged := changes
+	if len(logged) > maxLoggedChanges {
+		logged = logged[:maxLoggedChanges]
+	}
+	text := strings.Join(logged, "\n")
+	if len(changes) > len(logged) {
+		text += fmt.Sprintf("\n... and %d more", len(changes)-len(logged))
+	}
+	t.Logf("Rolling back %d config changes on %s:\n%s", len(changes), name, text)
+	if _, err := Artifacts(t).Write("config_rollback "+name, ArtifactText, []byte(strings.Join(changes, "\n")+"\n")); err != nil {
+		t.Logf("Could not write test output: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+func TestConfigChanges(t *testing.T) {
+	before := &oc.Root{}
+	before.GetOrCreateInterface("Ethernet1").Mtu = ygot.Uint16(1500)
+	before.GetOrCreateInterface("Ethernet2").Description = ygot.String("uplink")
+
+	after := &oc.Root{}
+	after.GetOrCreateInterface("Ethernet1").Mtu = ygot.Uint16(9000)
+	after.GetOrCreateNetworkInstance("VRF-A").Type = oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF
+
+	changes, err := configChanges(before, after)
+	if err != nil {
+		t.Fatalf("configChanges got error: %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		// Keep the sign and path, the value format is up to gNMI.
+		got = append(got, strings.SplitN(c, ":", 2)[0])
+	}
+	want := []string{
+		"+ /interfaces/interface[name=Ethernet1]/config/mtu",
+		"- /interfaces/interface[name=Ethernet2]/config/description",
+		"- /interfaces/interface[name=Ethernet2]/config/name",
+		"- /interfaces/interface[name=Ethernet2]/name",
+		"+ /network-instances/network-instance[name=VRF-A]/config/name",
+		"+ /network-instances/network-instance[name=VRF-A]/config/type",
+		"+ /network-instances/network-instance[name=VRF-A]/name",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("configChanges diff (-want +got):\n%s", diff)
+	}
+
+	if changes, err := configChanges(before, before); err != nil || len(changes) != 0 {
+		t.Errorf("configChanges of the same config got %v, %v; want no change", changes, err)
+	}
+}
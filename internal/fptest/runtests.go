@@ -15,10 +15,13 @@
 package fptest
 
 import (
+	"runtime"
+	"runtime/debug"
 	"testing"
 
-	"github.com/openconfig/featureprofiles/topologies/binding"
+	fpbinding "github.com/openconfig/featureprofiles/topologies/binding"
 	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/binding"
 )
 
 // RunTests initializes the appropriate binding and runs the tests.
@@ -31,6 +34,27 @@ import (
 //	func TestMain(m *testing.M) {
 //	  fptest.RunTests(m)
 //	}
+//
+// The test artifacts are written under -outputs_dir in the directory of the
+// package of the caller, see Artifacts.  The gNMI Set RPCs sent to the DUTs
+// are audited into set_audit.textproto in -outputs_dir, and into the
+// set_audit.textproto artifacts of the tests registered by AuditSets.  With
+// -debug_on_release, the debug data of the DUTs is collected when the
+// reservation is released after a test registered by AuditSets or
+// CollectDebugOnFailure failed.
 func RunTests(m *testing.M) {
-	ondatra.RunTests(m, binding.New)
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		var module string
+		if info, ok := debug.ReadBuildInfo(); ok {
+			module = info.Main.Path
+		}
+		testPackage = packagePath(runtime.FuncForPC(pc).Name(), module)
+	}
+	ondatra.RunTests(m, func() (binding.Binding, error) {
+		b, err := fpbinding.New()
+		if err != nil {
+			return nil, err
+		}
+		return auditSets(&debugBind{Binding: b}), nil
+	})
 }
@@ -0,0 +1,220 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	sapb "github.com/openconfig/featureprofiles/proto/set_audit_go_proto"
+	fpbinding "github.com/openconfig/featureprofiles/topologies/binding"
+)
+
+const (
+	gnmiSetMethod = "/gnmi.gNMI/Set"
+
+	// setAuditFile is the audit of the whole run in -outputs_dir.
+	setAuditFile = "set_audit.textproto"
+)
+
+// setAuditor records the gNMI Set RPCs sent to the DUTs.
+type setAuditor struct {
+	mu sync.Mutex
+	// outputsDir is the directory of the test artifacts, or "" to
+	// discard them.
+	outputsDir string
+	// runFile is the file which the audit of the whole run is
+	// appended to, or "" to skip it.
+	runFile string
+	// testFiles are the set_audit artifacts of the tests by test name;
+	// "" if the audit of the test is discarded.
+	testFiles map[string]string
+	// running are the tests registered by AuditSets which have not
+	// ended yet.
+	running map[string]bool
+}
+
+var auditor = &setAuditor{}
+
+// interceptor records the gNMI Set RPCs sent to the DUT.  It is
+// installed on the DUT gNMI connections by RunTests.
+func (a *setAuditor) interceptor(dut binding.DUT) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method != gnmiSetMethod {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		a.record(dut.Name(), start, time.Since(start), req, reply, err)
+		return err
+	}
+}
+
+// AuditSets registers the test for the gNMI Set audit until it ends: the
+// Set RPCs sent to the DUTs meanwhile are written to its set_audit.textproto
+// artifact.  A subtest registered while its parent test is registered
+// takes the Sets over until it ends.  The Sets sent while several tests
+// run in parallel are written to the artifacts of all of them, since the
+// test which sent them cannot be told apart.  A failure of the test is
+// recorded for -debug_on_release.
+func AuditSets(t testing.TB) {
+	watchFailure(t)
+	auditor.start(t.Name())
+	t.Cleanup(func() { auditor.end(t.Name()) })
+}
+
+func (a *setAuditor) start(test string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.running == nil {
+		a.running = make(map[string]bool)
+	}
+	a.running[test] = true
+}
+
+func (a *setAuditor) end(test string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.running, test)
+}
+
+// runningTests returns the registered tests which have no registered
+// subtest running, sorted by name.
+func (a *setAuditor) runningTests() []string {
+	var tests []string
+	for test := range a.running {
+		leaf := true
+		for other := range a.running {
+			if strings.HasPrefix(other, test+"/") {
+				leaf = false
+				break
+			}
+		}
+		if leaf {
+			tests = append(tests, test)
+		}
+	}
+	sort.Strings(tests)
+	return tests
+}
+
+// record adds a Set RPC to the audit of the run and to the audits of the
+// running tests.  The test of the run audit entry lists the running tests
+// separated by commas.
+func (a *setAuditor) record(device string, start time.Time, d time.Duration, req, reply interface{}, err error) {
+	e := &sapb.SetAudit_Entry{
+		Device:   device,
+		Time:     timestamppb.New(start),
+		Duration: durationpb.New(d),
+		Code:     int32(status.Code(err)),
+	}
+	if m, ok := req.(proto.Message); ok {
+		if msg, aerr := anypb.New(m); aerr == nil {
+			e.Request = msg
+		}
+	}
+	if err != nil {
+		e.Error = err.Error()
+	} else if m, ok := reply.(proto.Message); ok {
+		if msg, aerr := anypb.New(m); aerr == nil {
+			e.Response = msg
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tests := a.runningTests()
+	if a.runFile != "" {
+		e.Test = strings.Join(tests, ",")
+		if err := appendEntry(a.runFile, e); err != nil {
+			log.Printf("Could not append to the gNMI Set audit %s: %v", a.runFile, err)
+		}
+	}
+	for _, test := range tests {
+		e.Test = test
+		if err := a.appendTest(e); err != nil {
+			log.Printf("Could not write the gNMI Set audit of %s: %v", test, err)
+		}
+	}
+}
+
+// appendTest appends the entry to the set_audit artifact of its test,
+// which is created with the first entry of the test.
+func (a *setAuditor) appendTest(e *sapb.SetAudit_Entry) error {
+	if a.testFiles == nil {
+		a.testFiles = make(map[string]string)
+	}
+	filename, ok := a.testFiles[e.Test]
+	if !ok {
+		content, err := prototext.MarshalOptions{Multiline: true}.Marshal(&sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{e}})
+		if err != nil {
+			return err
+		}
+		filename, err = newArtifactStore(a.outputsDir, testPackage, e.Test).Write("set_audit", ArtifactTextproto, content)
+		a.testFiles[e.Test] = filename
+		return err
+	}
+	if filename == "" {
+		return nil
+	}
+	return appendEntry(filename, e)
+}
+
+// appendEntry appends an entry to a SetAudit textproto file.  The
+// concatenation of SetAudit textprotos is itself a SetAudit textproto.
+func appendEntry(filename string, e *sapb.SetAudit_Entry) error {
+	content, err := prototext.MarshalOptions{Multiline: true}.Marshal(&sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{e}})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// auditSets installs the Set auditor on the DUT gNMI connections of the
+// binding.  The Set RPCs of the whole run are appended to
+// set_audit.textproto in -outputs_dir, and those of each test registered
+// by AuditSets to its set_audit.textproto artifact.  Both can be replayed
+// with tools/setreplay.
+func auditSets(b binding.Binding) binding.Binding {
+	if *outputsDir != "" {
+		auditor.outputsDir = *outputsDir
+		auditor.runFile = filepath.Join(*outputsDir, setAuditFile)
+	}
+	return fpbinding.WithGNMIInterceptor(b, auditor.interceptor)
+}
@@ -0,0 +1,169 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fptest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/ondatra/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	sapb "github.com/openconfig/featureprofiles/proto/set_audit_go_proto"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestSetAuditor(t *testing.T) {
+	outputs := t.TempDir()
+	runFile := filepath.Join(outputs, setAuditFile)
+	a := &setAuditor{outputsDir: outputs, runFile: runFile}
+	dut := &binding.AbstractDUT{Dims: &binding.Dims{Name: "dut1"}}
+	intercept := a.interceptor(dut)
+
+	okReq := &gpb.SetRequest{Delete: []*gpb.Path{{Elem: []*gpb.PathElem{{Name: "system"}}}}}
+	okResp := &gpb.SetResponse{Timestamp: 42}
+	badReq := &gpb.SetRequest{Replace: []*gpb.Update{{Path: &gpb.Path{}}}}
+	badErr := status.Error(codes.InvalidArgument, "bad config")
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if proto.Equal(req.(proto.Message), badReq) {
+			return badErr
+		}
+		if resp, ok := reply.(*gpb.SetResponse); ok {
+			proto.Merge(resp, okResp)
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	set := func(req *gpb.SetRequest) {
+		t.Helper()
+		if err := intercept(ctx, gnmiSetMethod, req, &gpb.SetResponse{}, nil, invoker); err != nil && req != badReq {
+			t.Fatalf("Set got error: %v", err)
+		}
+	}
+	// Without a registered test, the Set is only in the run audit.
+	set(okReq)
+	a.start("TestSetAuditor")
+	set(okReq)
+	if err := intercept(ctx, "/gnmi.gNMI/Get", &gpb.GetRequest{}, &gpb.GetResponse{}, nil, invoker); err != nil {
+		t.Fatalf("Get got error: %v", err)
+	}
+	a.start("TestSetAuditor/Subtest")
+	if err := intercept(ctx, gnmiSetMethod, badReq, &gpb.SetResponse{}, nil, invoker); err != badErr {
+		t.Fatalf("Set got error %v, want %v", err, badErr)
+	}
+	a.end("TestSetAuditor/Subtest")
+	// A Set from a goroutine of the test is attributed to the test.
+	errc := make(chan error)
+	go func() {
+		errc <- intercept(ctx, gnmiSetMethod, okReq, &gpb.SetResponse{}, nil, invoker)
+	}()
+	if err := <-errc; err != nil {
+		t.Fatalf("Set got error: %v", err)
+	}
+	// A Set while another test runs in parallel is attributed to both.
+	a.start("TestOther")
+	set(okReq)
+	a.end("TestOther")
+	a.end("TestSetAuditor")
+
+	mustAny := func(m proto.Message) *anypb.Any {
+		a, err := anypb.New(m)
+		if err != nil {
+			t.Fatalf("anypb.New got error: %v", err)
+		}
+		return a
+	}
+	entry := func(test string, req *gpb.SetRequest) *sapb.SetAudit_Entry {
+		e := &sapb.SetAudit_Entry{Test: test, Device: "dut1", Request: mustAny(req)}
+		if req == badReq {
+			e.Code = int32(codes.InvalidArgument)
+			e.Error = badErr.Error()
+		} else {
+			e.Response = mustAny(okResp)
+		}
+		return e
+	}
+	wantRun := &sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{
+		entry("", okReq),
+		entry("TestSetAuditor", okReq),
+		entry("TestSetAuditor/Subtest", badReq),
+		entry("TestSetAuditor", okReq),
+		entry("TestOther,TestSetAuditor", okReq),
+	}}
+	wantTest := &sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{
+		entry("TestSetAuditor", okReq),
+		entry("TestSetAuditor", okReq),
+		entry("TestSetAuditor", okReq),
+	}}
+	wantSubtest := &sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{entry("TestSetAuditor/Subtest", badReq)}}
+	wantOther := &sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{entry("TestOther", okReq)}}
+	ignoreTiming := protocmp.IgnoreFields(&sapb.SetAudit_Entry{}, "time", "duration")
+
+	readAudit := func(filename string) *sapb.SetAudit {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("Cannot read the audit: %v", err)
+		}
+		audit := &sapb.SetAudit{}
+		if err := prototext.Unmarshal(content, audit); err != nil {
+			t.Fatalf("Cannot parse the audit: %v", err)
+		}
+		return audit
+	}
+	for _, tt := range []struct {
+		file string
+		want *sapb.SetAudit
+	}{
+		{runFile, wantRun},
+		{filepath.Join(outputs, "TestSetAuditor", "set_audit.textproto"), wantTest},
+		{filepath.Join(outputs, "TestSetAuditor", "Subtest", "set_audit.textproto"), wantSubtest},
+		{filepath.Join(outputs, "TestOther", "set_audit.textproto"), wantOther},
+	} {
+		if diff := cmp.Diff(tt.want, readAudit(tt.file), protocmp.Transform(), ignoreTiming); diff != "" {
+			t.Errorf("Audit %s diff (-want +got):\n%s", tt.file, diff)
+		}
+	}
+	testFile := filepath.Join(outputs, "TestSetAuditor", "set_audit.textproto")
+	if got := readManifest(filepath.Dir(testFile)); len(got) != 1 || got[0].File != "set_audit.textproto" {
+		t.Errorf("Test manifest got %v, want the set_audit.textproto artifact", got)
+	}
+}
+
+func TestAuditSets(t *testing.T) {
+	AuditSets(t)
+	if got, want := auditor.runningTests(), []string{"TestAuditSets"}; !cmp.Equal(got, want) {
+		t.Errorf("runningTests got %v, want %v", got, want)
+	}
+	t.Run("Subtest", func(t *testing.T) {
+		AuditSets(t)
+		if got, want := auditor.runningTests(), []string{"TestAuditSets/Subtest"}; !cmp.Equal(got, want) {
+			t.Errorf("runningTests got %v, want %v", got, want)
+		}
+	})
+	if got, want := auditor.runningTests(), []string{"TestAuditSets"}; !cmp.Equal(got, want) {
+		t.Errorf("runningTests after the subtest got %v, want %v", got, want)
+	}
+}
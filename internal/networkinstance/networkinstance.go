@@ -0,0 +1,182 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package networkinstance builds the OpenConfig configuration of a network
+// instance, applying the deviations of the DUT that concern network instances:
+// the name of the default network instance, whether interfaces must be attached
+// explicitly to the default network instance, and the name of the static
+// protocol.
+//
+// A non-default VRF is typically built and pushed like this:
+//
+//	networkinstance.L3VRF(dut, "VRF-A").
+//		WithRouteDistinguisher("65000:1").
+//		WithInterface(p1.Name(), 0).
+//		WithStaticRoute("0.0.0.0/0", "192.0.2.2").
+//		Push(t, dut)
+package networkinstance
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// Builder accumulates the configuration of one network instance.
+type Builder struct {
+	dev *deviations.Deviations
+	ni  *oc.NetworkInstance
+}
+
+// L3VRF returns a builder of an L3VRF network instance with the given name.  If
+// the name is that of the default network instance of the device, the builder is
+// the same as Default.
+func L3VRF(dut deviations.Device, name string) *Builder {
+	dev := deviations.For(dut)
+	if name == dev.DefaultNetworkInstance() {
+		return Default(dut)
+	}
+	return &Builder{
+		dev: dev,
+		ni: &oc.NetworkInstance{
+			Name: ygot.String(name),
+			Type: oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF,
+		},
+	}
+}
+
+// Default returns a builder of the default network instance of the device.
+func Default(dut deviations.Device) *Builder {
+	dev := deviations.For(dut)
+	return &Builder{
+		dev: dev,
+		ni: &oc.NetworkInstance{
+			Name: ygot.String(dev.DefaultNetworkInstance()),
+			Type: oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_DEFAULT_INSTANCE,
+		},
+	}
+}
+
+// IsDefault returns true if the builder is for the default network instance.
+func (b *Builder) IsDefault() bool {
+	return b.ni.Type == oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_DEFAULT_INSTANCE
+}
+
+// Name returns the name of the network instance.
+func (b *Builder) Name() string {
+	return b.ni.GetName()
+}
+
+// WithDescription sets the description of the network instance.
+func (b *Builder) WithDescription(desc string) *Builder {
+	b.ni.Description = ygot.String(desc)
+	return b
+}
+
+// WithRouteDistinguisher sets the route distinguisher, e.g. "65000:1".
+func (b *Builder) WithRouteDistinguisher(rd string) *Builder {
+	b.ni.RouteDistinguisher = ygot.String(rd)
+	return b
+}
+
+// WithInterface attaches a subinterface to the network instance.  The interface
+// is identified as "<name>.<subinterface>".  Interfaces belong to the default
+// network instance implicitly, so they are only attached to it when the device
+// has the ExplicitInterfaceInDefaultVRF deviation.
+func (b *Builder) WithInterface(name string, subinterface uint32) *Builder {
+	return b.WithInterfaceID(name+"."+strconv.FormatUint(uint64(subinterface), 10), name, subinterface)
+}
+
+// WithInterfaceID is like WithInterface, but identifies the interface by the given
+// id, for tests that key the interfaces of the network instance differently.
+func (b *Builder) WithInterfaceID(id, name string, subinterface uint32) *Builder {
+	if b.IsDefault() && !b.dev.ExplicitInterfaceInDefaultVRF() {
+		return b
+	}
+	i := b.ni.GetOrCreateInterface(id)
+	i.Interface = ygot.String(name)
+	i.Subinterface = ygot.Uint32(subinterface)
+	return b
+}
+
+// staticProtocol returns the static protocol, named after the StaticProtocolName
+// deviation.
+func (b *Builder) staticProtocol() *oc.NetworkInstance_Protocol {
+	return b.ni.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, b.dev.StaticProtocolName())
+}
+
+// WithStaticProtocol creates the static protocol without any route.  Some devices
+// need it before accepting routes in the network instance, e.g. from gRIBI.
+func (b *Builder) WithStaticProtocol() *Builder {
+	b.staticProtocol()
+	return b
+}
+
+// WithStaticRoute adds a static route to the next hop addresses, which are
+// indexed "0", "1", ... in the order given.
+func (b *Builder) WithStaticRoute(prefix string, nextHops ...string) *Builder {
+	b.addStaticRoute(prefix, nextHops, false)
+	return b
+}
+
+// WithRecursiveStaticRoute is like WithStaticRoute, but the next hops may be
+// resolved recursively.
+func (b *Builder) WithRecursiveStaticRoute(prefix string, nextHops ...string) *Builder {
+	b.addStaticRoute(prefix, nextHops, true)
+	return b
+}
+
+func (b *Builder) addStaticRoute(prefix string, nextHops []string, recurse bool) {
+	s := b.staticProtocol().GetOrCreateStatic(prefix)
+	for i, addr := range nextHops {
+		nh := s.GetOrCreateNextHop(strconv.Itoa(i))
+		nh.NextHop = oc.UnionString(addr)
+		if recurse {
+			nh.Recurse = ygot.Bool(true)
+		}
+	}
+}
+
+// WithTableConnection connects the routes of the source protocol to the
+// destination protocol for the address family, through the import policies.
+func (b *Builder) WithTableConnection(src, dst oc.E_PolicyTypes_INSTALL_PROTOCOL_TYPE, af oc.E_Types_ADDRESS_FAMILY, importPolicies ...string) *Builder {
+	tc := b.ni.GetOrCreateTableConnection(src, dst, af)
+	tc.ImportPolicy = append(tc.ImportPolicy, importPolicies...)
+	return b
+}
+
+// Build returns the network instance configuration.  The builder should not be
+// used afterwards.
+func (b *Builder) Build() *oc.NetworkInstance {
+	return b.ni
+}
+
+// Push configures the network instance on the DUT.  A non-default network
+// instance is replaced as a whole so that it is created from scratch, whereas the
+// default network instance is updated so that the configuration made elsewhere,
+// e.g. protocols and policy forwarding, is kept.
+func (b *Builder) Push(t testing.TB, dut *ondatra.DUTDevice) {
+	t.Helper()
+	q := gnmi.OC().NetworkInstance(b.ni.GetName()).Config()
+	if b.IsDefault() {
+		gnmi.Update(t, dut, q, b.ni)
+		return
+	}
+	gnmi.Replace(t, dut, q, b.ni)
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkinstance
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	"github.com/openconfig/ondatra/gnmi/oc"
+	"github.com/openconfig/ygot/ygot"
+)
+
+type fakeDevice string
+
+func (d fakeDevice) Name() string { return string(d) }
+
+func TestL3VRF(t *testing.T) {
+	if err := deviations.SetDevice("dut.vrf", map[string]string{
+		"deviation_static_protocol_name": "STATIC",
+	}); err != nil {
+		t.Fatalf("SetDevice got error: %v", err)
+	}
+
+	got := L3VRF(fakeDevice("dut.vrf"), "VRF-A").
+		WithDescription("test vrf").
+		WithRouteDistinguisher("65000:1").
+		WithInterface("Ethernet1", 0).
+		WithInterface("Ethernet2", 10).
+		WithInterfaceID("Ethernet3", "Ethernet3", 0).
+		WithStaticRoute("192.0.2.0/24", "198.51.100.1", "198.51.100.2").
+		WithRecursiveStaticRoute("::/0", "2001:db8::1").
+		WithTableConnection(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, oc.Types_ADDRESS_FAMILY_IPV4, "ALLOW").
+		Build()
+
+	want := &oc.NetworkInstance{
+		Name:               ygot.String("VRF-A"),
+		Type:               oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_L3VRF,
+		Description:        ygot.String("test vrf"),
+		RouteDistinguisher: ygot.String("65000:1"),
+	}
+	for _, i := range []struct {
+		name string
+		sub  uint32
+		id   string
+	}{{"Ethernet1", 0, "Ethernet1.0"}, {"Ethernet2", 10, "Ethernet2.10"}, {"Ethernet3", 0, "Ethernet3"}} {
+		ni := want.GetOrCreateInterface(i.id)
+		ni.Interface = ygot.String(i.name)
+		ni.Subinterface = ygot.Uint32(i.sub)
+	}
+	static := want.GetOrCreateProtocol(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, "STATIC")
+	s4 := static.GetOrCreateStatic("192.0.2.0/24")
+	s4.GetOrCreateNextHop("0").NextHop = oc.UnionString("198.51.100.1")
+	s4.GetOrCreateNextHop("1").NextHop = oc.UnionString("198.51.100.2")
+	nh6 := static.GetOrCreateStatic("::/0").GetOrCreateNextHop("0")
+	nh6.NextHop = oc.UnionString("2001:db8::1")
+	nh6.Recurse = ygot.Bool(true)
+	want.GetOrCreateTableConnection(oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_STATIC, oc.PolicyTypes_INSTALL_PROTOCOL_TYPE_BGP, oc.Types_ADDRESS_FAMILY_IPV4).ImportPolicy = []string{"ALLOW"}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("L3VRF config diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	cases := []struct {
+		desc     string
+		values   map[string]string
+		name     string
+		wantName string
+		wantIntf bool
+	}{{
+		desc:     "implicit interfaces",
+		values:   map[string]string{"deviation_default_network_instance": "default"},
+		name:     "default",
+		wantName: "default",
+	}, {
+		desc: "explicit interfaces",
+		values: map[string]string{
+			"deviation_default_network_instance":          "default",
+			"deviation_explicit_interface_in_default_vrf": "true",
+		},
+		name:     "default",
+		wantName: "default",
+		wantIntf: true,
+	}, {
+		desc:     "vrf named after the default",
+		values:   map[string]string{},
+		name:     "DEFAULT",
+		wantName: "DEFAULT",
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			dut := fakeDevice("dut.default." + c.desc)
+			if err := deviations.SetDevice(dut.Name(), c.values); err != nil {
+				t.Fatalf("SetDevice got error: %v", err)
+			}
+			b := L3VRF(dut, c.name).WithInterface("Ethernet1", 0)
+			if !b.IsDefault() {
+				t.Errorf("L3VRF(%q).IsDefault() got false, want true", c.name)
+			}
+			ni := b.Build()
+			if got := ni.GetName(); got != c.wantName {
+				t.Errorf("Name got %q, want %q", got, c.wantName)
+			}
+			if got, want := ni.Type, oc.NetworkInstanceTypes_NETWORK_INSTANCE_TYPE_DEFAULT_INSTANCE; got != want {
+				t.Errorf("Type got %v, want %v", got, want)
+			}
+			if got := ni.GetInterface("Ethernet1.0") != nil; got != c.wantIntf {
+				t.Errorf("Interface attached got %v, want %v", got, c.wantIntf)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ondatra/otg"
+
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+)
+
+// The assertions below read the OTG telemetry of flows and ports, and report a
+// test error with the metrics table when the traffic is not as expected.  They
+// return true if the assertion holds, so that a test may stop or skip further
+// checks.
+
+// ExpectNoLoss checks that every packet sent by the flows was received.
+func ExpectNoLoss(t testing.TB, otg *otg.OTG, flows ...string) bool {
+	t.Helper()
+	metrics := make([]*otgtelemetry.Flow, len(flows))
+	var errs []string
+	for i, name := range flows {
+		metrics[i] = getFlow(t, otg, name)
+		if err := checkLoss(metrics[i], 0, 0); err != "" {
+			errs = append(errs, err)
+		}
+	}
+	return reportFlows(t, errs, metrics)
+}
+
+// ExpectLossPercent checks that the loss of the flow is within [lo, hi] percent,
+// e.g. 100, 100 for traffic which must be dropped.
+func ExpectLossPercent(t testing.TB, otg *otg.OTG, flow string, lo, hi float64) bool {
+	t.Helper()
+	f := getFlow(t, otg, flow)
+	var errs []string
+	if err := checkLoss(f, lo, hi); err != "" {
+		errs = append(errs, err)
+	}
+	return reportFlows(t, errs, []*otgtelemetry.Flow{f})
+}
+
+// ExpectRateWithin checks that the receive frame rate of the flow is within [lo,
+// hi] frames per second.  It must be called while the traffic is running.
+func ExpectRateWithin(t testing.TB, otg *otg.OTG, flow string, lo, hi float32) bool {
+	t.Helper()
+	f := getFlow(t, otg, flow)
+	var errs []string
+	if err := checkRate(f, lo, hi); err != "" {
+		errs = append(errs, err)
+	}
+	return reportFlows(t, errs, []*otgtelemetry.Flow{f})
+}
+
+// ExpectDistribution checks that the frames received by the ports are
+// distributed according to the weights, e.g. by ECMP or WCMP.  The share of
+// every port may differ from its weight by at most the given relative error,
+// e.g. 0.05 for 5%.
+func ExpectDistribution(t testing.TB, otg *otg.OTG, ports []string, weights []float64, tolerance float64) bool {
+	t.Helper()
+	if len(ports) != len(weights) {
+		t.Fatalf("ExpectDistribution: got %d ports and %d weights, want the same number", len(ports), len(weights))
+	}
+	metrics := make([]*otgtelemetry.Port, len(ports))
+	for i, name := range ports {
+		metrics[i] = getPort(t, otg, name)
+	}
+	errs := checkDistribution(metrics, weights, tolerance)
+	if len(errs) == 0 {
+		return true
+	}
+	t.Errorf("%s\n%s", strings.Join(errs, "\n"), portMetricsTable(metrics))
+	return false
+}
+
+// reportFlows reports the errors of flows with their metrics table.
+func reportFlows(t testing.TB, errs []string, flows []*otgtelemetry.Flow) bool {
+	t.Helper()
+	if len(errs) == 0 {
+		return true
+	}
+	t.Errorf("%s\n%s", strings.Join(errs, "\n"), flowMetricsTable(flows))
+	return false
+}
+
+// lossPercent returns the loss of a flow in percent from its counters, since
+// the loss-pct leaf is not reported by every OTG implementation.
+func lossPercent(f *otgtelemetry.Flow) (float64, bool) {
+	tx := f.GetCounters().GetOutPkts()
+	if tx == 0 {
+		return 0, false
+	}
+	rx := f.GetCounters().GetInPkts()
+	return (float64(tx) - float64(rx)) * 100 / float64(tx), true
+}
+
+// checkLoss returns an error message unless the loss of the flow is within [lo,
+// hi] percent.
+func checkLoss(f *otgtelemetry.Flow, lo, hi float64) string {
+	loss, ok := lossPercent(f)
+	switch {
+	case !ok:
+		return fmt.Sprintf("Flow %s sent no packets", f.GetName())
+	case loss < lo || loss > hi:
+		return fmt.Sprintf("Flow %s loss got %.3f%% (sent %d, received %d), want %s",
+			f.GetName(), loss, f.GetCounters().GetOutPkts(), f.GetCounters().GetInPkts(), percentRange(lo, hi))
+	}
+	return ""
+}
+
+func percentRange(lo, hi float64) string {
+	if lo == hi {
+		return fmt.Sprintf("%g%%", lo)
+	}
+	return fmt.Sprintf("[%g%%, %g%%]", lo, hi)
+}
+
+// checkRate returns an error message unless the receive frame rate of the flow
+// is within [lo, hi].
+func checkRate(f *otgtelemetry.Flow, lo, hi float32) string {
+	rate := binaryToFloat32(f.GetInFrameRate())
+	if rate < lo || rate > hi {
+		return fmt.Sprintf("Flow %s receive rate got %v fps, want [%v, %v] fps", f.GetName(), rate, lo, hi)
+	}
+	return ""
+}
+
+// checkDistribution returns an error message for every port whose share of the
+// frames received differs from its weight by more than the relative tolerance.
+func checkDistribution(ports []*otgtelemetry.Port, weights []float64, tolerance float64) []string {
+	var total uint64
+	var totalWeight float64
+	for i, p := range ports {
+		total += p.GetCounters().GetInFrames()
+		totalWeight += weights[i]
+	}
+	if total == 0 {
+		return []string{"No frames received by the ports"}
+	}
+	if totalWeight <= 0 {
+		return []string{fmt.Sprintf("Invalid weights %v", weights)}
+	}
+	var errs []string
+	for i, p := range ports {
+		got := float64(p.GetCounters().GetInFrames()) / float64(total)
+		want := weights[i] / totalWeight
+		if relativeError(got, want) > tolerance {
+			errs = append(errs, fmt.Sprintf("Port %s share of the frames got %.2f%% (%d of %d), want %.2f%% ± %g%%",
+				p.GetName(), got*100, p.GetCounters().GetInFrames(), total, want*100, tolerance*100))
+		}
+	}
+	return errs
+}
+
+// relativeError returns the error of got relative to want.  A share of zero must
+// be exact.
+func relativeError(got, want float64) float64 {
+	if want == 0 {
+		if got == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return math.Abs(got-want) / want
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/ygot/ygot"
+
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+)
+
+func float32ToBinary(f float32) otgtelemetry.Binary {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, math.Float32bits(f))
+	return b
+}
+
+func flow(name string, tx, rx uint64, rxRate float32) *otgtelemetry.Flow {
+	return &otgtelemetry.Flow{
+		Name: ygot.String(name),
+		Counters: &otgtelemetry.Flow_Counters{
+			OutPkts: ygot.Uint64(tx),
+			InPkts:  ygot.Uint64(rx),
+		},
+		InFrameRate: float32ToBinary(rxRate),
+	}
+}
+
+func port(name string, rx uint64) *otgtelemetry.Port {
+	return &otgtelemetry.Port{
+		Name:     ygot.String(name),
+		Counters: &otgtelemetry.Port_Counters{InFrames: ygot.Uint64(rx)},
+	}
+}
+
+func TestCheckLoss(t *testing.T) {
+	cases := []struct {
+		desc    string
+		flow    *otgtelemetry.Flow
+		lo, hi  float64
+		wantErr string
+	}{{
+		desc: "no loss",
+		flow: flow("f1", 1000, 1000, 0),
+	}, {
+		desc:    "some loss",
+		flow:    flow("f1", 1000, 990, 0),
+		wantErr: "Flow f1 loss got 1.000% (sent 1000, received 990), want 0%",
+	}, {
+		desc: "loss within range",
+		flow: flow("f1", 1000, 990, 0),
+		hi:   2,
+	}, {
+		desc: "all dropped",
+		flow: flow("f1", 1000, 0, 0),
+		lo:   100,
+		hi:   100,
+	}, {
+		desc:    "not dropped",
+		flow:    flow("f1", 1000, 500, 0),
+		lo:      99,
+		hi:      100,
+		wantErr: "Flow f1 loss got 50.000% (sent 1000, received 500), want [99%, 100%]",
+	}, {
+		desc:    "nothing sent",
+		flow:    flow("f1", 0, 0, 0),
+		wantErr: "Flow f1 sent no packets",
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := checkLoss(c.flow, c.lo, c.hi); got != c.wantErr {
+				t.Errorf("checkLoss got %q, want %q", got, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckRate(t *testing.T) {
+	if got := checkRate(flow("f1", 0, 0, 1000), 950, 1050); got != "" {
+		t.Errorf("checkRate of 1000 fps within [950, 1050] got %q, want no error", got)
+	}
+	want := "Flow f1 receive rate got 900 fps, want [950, 1050] fps"
+	if got := checkRate(flow("f1", 0, 0, 900), 950, 1050); got != want {
+		t.Errorf("checkRate of 900 fps within [950, 1050] got %q, want %q", got, want)
+	}
+}
+
+func TestCheckDistribution(t *testing.T) {
+	cases := []struct {
+		desc      string
+		ports     []*otgtelemetry.Port
+		weights   []float64
+		tolerance float64
+		wantErrs  []string
+	}{{
+		desc:      "even",
+		ports:     []*otgtelemetry.Port{port("p2", 510), port("p3", 490)},
+		weights:   []float64{1, 1},
+		tolerance: 0.05,
+	}, {
+		desc:      "weighted",
+		ports:     []*otgtelemetry.Port{port("p2", 740), port("p3", 260)},
+		weights:   []float64{3, 1},
+		tolerance: 0.05,
+	}, {
+		desc:      "uneven",
+		ports:     []*otgtelemetry.Port{port("p2", 700), port("p3", 300)},
+		weights:   []float64{1, 1},
+		tolerance: 0.1,
+		wantErrs: []string{
+			"Port p2 share of the frames got 70.00% (700 of 1000), want 50.00% ± 10%",
+			"Port p3 share of the frames got 30.00% (300 of 1000), want 50.00% ± 10%",
+		},
+	}, {
+		desc:      "zero weight",
+		ports:     []*otgtelemetry.Port{port("p2", 999), port("p3", 1)},
+		weights:   []float64{1, 0},
+		tolerance: 0.5,
+		wantErrs: []string{
+			"Port p3 share of the frames got 0.10% (1 of 1000), want 0.00% ± 50%",
+		},
+	}, {
+		desc:     "nothing received",
+		ports:    []*otgtelemetry.Port{port("p2", 0), port("p3", 0)},
+		weights:  []float64{1, 1},
+		wantErrs: []string{"No frames received by the ports"},
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			got := checkDistribution(c.ports, c.weights, c.tolerance)
+			if strings.Join(got, "\n") != strings.Join(c.wantErrs, "\n") {
+				t.Errorf("checkDistribution got %q, want %q", got, c.wantErrs)
+			}
+		})
+	}
+}
+
+func TestFlowMetricsTable(t *testing.T) {
+	got := flowMetricsTable([]*otgtelemetry.Flow{flow("f1", 1000, 990, 100)})
+	for _, want := range []string{"OTG Flow Metrics", "Frames Tx", "f1", "1000", "990"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("flowMetricsTable got:\n%s\nwant it to contain %q", got, want)
+		}
+	}
+}
@@ -30,27 +30,64 @@ import (
 // LogFlowMetrics displays the otg flow statistics.
 func LogFlowMetrics(t testing.TB, otg *otg.OTG, c gosnappi.Config) {
 	t.Helper()
+	var flows []*otgtelemetry.Flow
+	for _, f := range c.Flows().Items() {
+		flows = append(flows, getFlow(t, otg, f.Name()))
+	}
+	t.Log(flowMetricsTable(flows))
+}
+
+// getFlow returns the metrics of a flow, named even if the OTG omits the name.
+func getFlow(t testing.TB, otg *otg.OTG, name string) *otgtelemetry.Flow {
+	t.Helper()
+	f := gnmi.Get(t, otg, gnmi.OTG().Flow(name).State())
+	if f.Name == nil {
+		f.Name = ygot.String(name)
+	}
+	return f
+}
+
+// flowMetricsTable formats the otg flow statistics.
+func flowMetricsTable(flows []*otgtelemetry.Flow) string {
 	var out strings.Builder
 	out.WriteString("\nOTG Flow Metrics\n")
 	fmt.Fprintln(&out, strings.Repeat("-", 80))
 	out.WriteString("\n")
 	fmt.Fprintf(&out, "%-25v%-15v%-15v%-15v%-15v\n", "Name", "Frames Tx", "Frames Rx", "FPS Tx", "FPS Rx")
-	for _, f := range c.Flows().Items() {
-		flowMetrics := gnmi.Get(t, otg, gnmi.OTG().Flow(f.Name()).State())
+	for _, flowMetrics := range flows {
 		rxPkts := flowMetrics.GetCounters().GetInPkts()
 		txPkts := flowMetrics.GetCounters().GetOutPkts()
-		rxRate := ygot.BinaryToFloat32(flowMetrics.GetInFrameRate())
-		txRate := ygot.BinaryToFloat32(flowMetrics.GetOutFrameRate())
-		out.WriteString(fmt.Sprintf("%-25v%-15v%-15v%-15v%-15v\n", f.Name(), txPkts, rxPkts, txRate, rxRate))
+		rxRate := binaryToFloat32(flowMetrics.GetInFrameRate())
+		txRate := binaryToFloat32(flowMetrics.GetOutFrameRate())
+		out.WriteString(fmt.Sprintf("%-25v%-15v%-15v%-15v%-15v\n", flowMetrics.GetName(), txPkts, rxPkts, txRate, rxRate))
 	}
 	fmt.Fprintln(&out, strings.Repeat("-", 80))
 	out.WriteString("\n\n")
-	t.Log(out.String())
+	return out.String()
 }
 
 // LogPortMetrics displays otg port stats.
 func LogPortMetrics(t testing.TB, otg *otg.OTG, c gosnappi.Config) {
 	t.Helper()
+	var ports []*otgtelemetry.Port
+	for _, p := range c.Ports().Items() {
+		ports = append(ports, getPort(t, otg, p.Name()))
+	}
+	t.Log(portMetricsTable(ports))
+}
+
+// getPort returns the metrics of a port, named even if the OTG omits the name.
+func getPort(t testing.TB, otg *otg.OTG, name string) *otgtelemetry.Port {
+	t.Helper()
+	p := gnmi.Get(t, otg, gnmi.OTG().Port(name).State())
+	if p.Name == nil {
+		p.Name = ygot.String(name)
+	}
+	return p
+}
+
+// portMetricsTable formats the otg port stats.
+func portMetricsTable(ports []*otgtelemetry.Port) string {
 	var link string
 	var out strings.Builder
 	out.WriteString("\nOTG Port Metrics\n")
@@ -59,12 +96,11 @@ func LogPortMetrics(t testing.TB, otg *otg.OTG, c gosnappi.Config) {
 	fmt.Fprintf(&out,
 		"%-25s%-15s%-15s%-15s%-15s%-15s%-15s%-15s\n",
 		"Name", "Frames Tx", "Frames Rx", "Bytes Tx", "Bytes Rx", "FPS Tx", "FPS Rx", "Link")
-	for _, p := range c.Ports().Items() {
-		portMetrics := gnmi.Get(t, otg, gnmi.OTG().Port(p.Name()).State())
+	for _, portMetrics := range ports {
 		rxFrames := portMetrics.GetCounters().GetInFrames()
 		txFrames := portMetrics.GetCounters().GetOutFrames()
-		rxRate := ygot.BinaryToFloat32(portMetrics.GetInRate())
-		txRate := ygot.BinaryToFloat32(portMetrics.GetOutRate())
+		rxRate := binaryToFloat32(portMetrics.GetInRate())
+		txRate := binaryToFloat32(portMetrics.GetOutRate())
 		rxBytes := portMetrics.GetCounters().GetInOctets()
 		txBytes := portMetrics.GetCounters().GetOutOctets()
 		link = "down"
@@ -73,12 +109,12 @@ func LogPortMetrics(t testing.TB, otg *otg.OTG, c gosnappi.Config) {
 		}
 		out.WriteString(fmt.Sprintf(
 			"%-25v%-15v%-15v%-15v%-15v%-15v%-15v%-15v\n",
-			p.Name(), txFrames, rxFrames, txBytes, rxBytes, txRate, rxRate, link,
+			portMetrics.GetName(), txFrames, rxFrames, txBytes, rxBytes, txRate, rxRate, link,
 		))
 	}
 	fmt.Fprintln(&out, strings.Repeat("-", 120))
 	out.WriteString("\n\n")
-	t.Log(out.String())
+	return out.String()
 }
 
 // LogLAGMetrics is displaying otg lag stats.
@@ -144,3 +180,11 @@ func LogLACPMetrics(t testing.TB, otg *otg.OTG, c gosnappi.Config) {
 	out.WriteString("\n\n")
 	t.Log(out.String())
 }
+
+// binaryToFloat32 decodes a rate, which is 0 if the OTG does not report it.
+func binaryToFloat32(b otgtelemetry.Binary) float32 {
+	if len(b) < 4 {
+		return 0
+	}
+	return ygot.BinaryToFloat32(b)
+}
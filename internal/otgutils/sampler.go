@@ -0,0 +1,201 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/fptest"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/otg"
+)
+
+// Sources and kinds of samples.
+const (
+	SourceOTG = "otg"
+
+	KindFlow      = "flow"
+	KindPort      = "port"
+	KindInterface = "interface"
+	KindQueue     = "queue"
+)
+
+// Sample is one metric of a flow, port, interface or queue at a time.  A time
+// series is the samples with the same source, kind, name and metric.
+type Sample struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"` // SourceOTG or the name of the DUT.
+	Kind   string    `json:"kind"`
+	Name   string    `json:"name"`
+	Metric string    `json:"metric"`
+	Value  float64   `json:"value"`
+}
+
+// Sampler polls the metrics of the OTG flows and ports and, optionally, the
+// counters of DUT interfaces and their QoS output queues, while traffic runs.
+// Metrics which are not reported are left out of the samples.
+//
+//	s := otgutils.NewSampler(ate.OTG(), config).WithDUT(dut, dp2.Name())
+//	ate.OTG().StartTraffic(t)
+//	s.Run(t, 30*time.Second, time.Second)
+//	ate.OTG().StopTraffic(t)
+//	s.WriteArtifacts(t, "traffic")
+type Sampler struct {
+	otg        *otg.OTG
+	flows      []string
+	ports      []string
+	dut        *ondatra.DUTDevice
+	interfaces []string
+	samples    []*Sample
+}
+
+// NewSampler returns a sampler of the flows and ports in the OTG config.
+func NewSampler(otg *otg.OTG, c gosnappi.Config) *Sampler {
+	s := &Sampler{otg: otg}
+	for _, f := range c.Flows().Items() {
+		s.flows = append(s.flows, f.Name())
+	}
+	for _, p := range c.Ports().Items() {
+		s.ports = append(s.ports, p.Name())
+	}
+	return s
+}
+
+// WithDUT also samples the counters of the DUT interfaces and of their QoS
+// output queues.
+func (s *Sampler) WithDUT(dut *ondatra.DUTDevice, interfaces ...string) *Sampler {
+	s.dut = dut
+	s.interfaces = append(s.interfaces, interfaces...)
+	return s
+}
+
+// Run samples the metrics every interval for the duration.
+func (s *Sampler) Run(t testing.TB, duration, interval time.Duration) {
+	t.Helper()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	end := time.Now().Add(duration)
+	for {
+		s.Sample(t)
+		if !time.Now().Add(interval).Before(end) {
+			return
+		}
+		<-ticker.C
+	}
+}
+
+// Sample takes one sample of every metric.
+func (s *Sampler) Sample(t testing.TB) {
+	t.Helper()
+	now := time.Now()
+	add := func(source, kind, name, metric string, v float64) {
+		s.samples = append(s.samples, &Sample{
+			Time:   now,
+			Source: source,
+			Kind:   kind,
+			Name:   name,
+			Metric: metric,
+			Value:  v,
+		})
+	}
+
+	for _, name := range s.flows {
+		f, ok := gnmi.Lookup(t, s.otg, gnmi.OTG().Flow(name).State()).Val()
+		if !ok {
+			continue
+		}
+		add(SourceOTG, KindFlow, name, "tx_frames", float64(f.GetCounters().GetOutPkts()))
+		add(SourceOTG, KindFlow, name, "rx_frames", float64(f.GetCounters().GetInPkts()))
+		add(SourceOTG, KindFlow, name, "tx_bytes", float64(f.GetCounters().GetOutOctets()))
+		add(SourceOTG, KindFlow, name, "rx_bytes", float64(f.GetCounters().GetInOctets()))
+		add(SourceOTG, KindFlow, name, "tx_fps", float64(binaryToFloat32(f.GetOutFrameRate())))
+		add(SourceOTG, KindFlow, name, "rx_fps", float64(binaryToFloat32(f.GetInFrameRate())))
+	}
+	for _, name := range s.ports {
+		p, ok := gnmi.Lookup(t, s.otg, gnmi.OTG().Port(name).State()).Val()
+		if !ok {
+			continue
+		}
+		add(SourceOTG, KindPort, name, "tx_frames", float64(p.GetCounters().GetOutFrames()))
+		add(SourceOTG, KindPort, name, "rx_frames", float64(p.GetCounters().GetInFrames()))
+		add(SourceOTG, KindPort, name, "tx_bytes", float64(p.GetCounters().GetOutOctets()))
+		add(SourceOTG, KindPort, name, "rx_bytes", float64(p.GetCounters().GetInOctets()))
+		add(SourceOTG, KindPort, name, "tx_rate", float64(binaryToFloat32(p.GetOutRate())))
+		add(SourceOTG, KindPort, name, "rx_rate", float64(binaryToFloat32(p.GetInRate())))
+	}
+
+	if s.dut == nil {
+		return
+	}
+	source := s.dut.Name()
+	for _, name := range s.interfaces {
+		if c, ok := gnmi.Lookup(t, s.dut, gnmi.OC().Interface(name).Counters().State()).Val(); ok {
+			add(source, KindInterface, name, "in_pkts", float64(c.GetInPkts()))
+			add(source, KindInterface, name, "out_pkts", float64(c.GetOutPkts()))
+			add(source, KindInterface, name, "in_octets", float64(c.GetInOctets()))
+			add(source, KindInterface, name, "out_octets", float64(c.GetOutOctets()))
+			add(source, KindInterface, name, "in_discards", float64(c.GetInDiscards()))
+			add(source, KindInterface, name, "out_discards", float64(c.GetOutDiscards()))
+		}
+		for _, v := range gnmi.LookupAll(t, s.dut, gnmi.OC().Qos().Interface(name).Output().QueueAny().State()) {
+			q, ok := v.Val()
+			if !ok {
+				continue
+			}
+			queue := name + "/" + q.GetName()
+			add(source, KindQueue, queue, "transmit_pkts", float64(q.GetTransmitPkts()))
+			add(source, KindQueue, queue, "transmit_octets", float64(q.GetTransmitOctets()))
+			add(source, KindQueue, queue, "dropped_pkts", float64(q.GetDroppedPkts()))
+		}
+	}
+}
+
+// Samples returns the samples taken so far.
+func (s *Sampler) Samples() []*Sample {
+	return s.samples
+}
+
+// WriteArtifacts writes the samples as the CSV and JSON artifacts of the test
+// with the given name.
+func (s *Sampler) WriteArtifacts(t testing.TB, name string) {
+	t.Helper()
+	a := fptest.Artifacts(t)
+	if _, err := a.WriteCSV(name, samplesCSV(s.samples)); err != nil {
+		t.Errorf("Cannot write the samples as CSV: %v", err)
+	}
+	if _, err := a.WriteJSON(name, s.samples); err != nil {
+		t.Errorf("Cannot write the samples as JSON: %v", err)
+	}
+}
+
+// samplesCSV returns the samples as CSV records with a header.
+func samplesCSV(samples []*Sample) [][]string {
+	records := [][]string{{"time", "source", "kind", "name", "metric", "value"}}
+	for _, s := range samples {
+		records = append(records, []string{
+			s.Time.Format(time.RFC3339Nano),
+			s.Source,
+			s.Kind,
+			s.Name,
+			s.Metric,
+			strconv.FormatFloat(s.Value, 'f', -1, 64),
+		})
+	}
+	return records
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func TestNewSampler(t *testing.T) {
+	c := gosnappi.NewConfig()
+	c.Ports().Add().SetName("port1")
+	c.Ports().Add().SetName("port2")
+	c.Flows().Add().SetName("flow1")
+
+	s := NewSampler(nil, c)
+	if diff := cmp.Diff([]string{"flow1"}, s.flows); diff != "" {
+		t.Errorf("NewSampler flows diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"port1", "port2"}, s.ports); diff != "" {
+		t.Errorf("NewSampler ports diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSamplesCSV(t *testing.T) {
+	ts := time.Date(2022, 12, 1, 10, 0, 0, 500000000, time.UTC)
+	got := samplesCSV([]*Sample{
+		{Time: ts, Source: SourceOTG, Kind: KindFlow, Name: "flow1", Metric: "rx_fps", Value: 1000.5},
+		{Time: ts, Source: "dut", Kind: KindQueue, Name: "Ethernet1/AF4", Metric: "dropped_pkts", Value: 12},
+	})
+	want := [][]string{
+		{"time", "source", "kind", "name", "metric", "value"},
+		{"2022-12-01T10:00:00.5Z", "otg", "flow", "flow1", "rx_fps", "1000.5"},
+		{"2022-12-01T10:00:00.5Z", "dut", "queue", "Ethernet1/AF4", "dropped_pkts", "12"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("samplesCSV diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/ondatra/gnmi"
+	"github.com/openconfig/ondatra/otg"
+	"github.com/openconfig/ygnmi/ygnmi"
+
+	otgtelemetry "github.com/openconfig/ondatra/gnmi/otg"
+)
+
+// readiness is the status of an OTG interface, BGP peer or IS-IS router once
+// the wait is over.
+type readiness struct {
+	name   string
+	status string
+	ready  bool
+}
+
+// awaiter waits for one interface, BGP peer or IS-IS router to be ready.
+type awaiter func(t testing.TB) readiness
+
+// WaitForARP waits until the IPv4 neighbors of every OTG interface with an IPv4
+// address in the config are resolved.  It fails the test with the status of
+// every interface on timeout.
+func WaitForARP(t testing.TB, otg *otg.OTG, c gosnappi.Config, timeout time.Duration) {
+	t.Helper()
+	var awaiters []awaiter
+	for _, name := range ipv4Interfaces(c) {
+		name := name
+		w := gnmi.WatchAll(t, otg, gnmi.OTG().Interface(name).Ipv4NeighborAny().LinkLayerAddress().State(), timeout, func(v *ygnmi.Value[string]) bool {
+			return v.IsPresent()
+		})
+		awaiters = append(awaiters, func(t testing.TB) readiness {
+			v, ok := w.Await(t)
+			return neighborReadiness(name, v, ok)
+		})
+	}
+	await(t, "ARP resolution", "Interface", awaiters)
+}
+
+// WaitForND waits until the IPv6 neighbors of every OTG interface with an IPv6
+// address in the config are resolved.  It fails the test with the status of
+// every interface on timeout.
+func WaitForND(t testing.TB, otg *otg.OTG, c gosnappi.Config, timeout time.Duration) {
+	t.Helper()
+	var awaiters []awaiter
+	for _, name := range ipv6Interfaces(c) {
+		name := name
+		w := gnmi.WatchAll(t, otg, gnmi.OTG().Interface(name).Ipv6NeighborAny().LinkLayerAddress().State(), timeout, func(v *ygnmi.Value[string]) bool {
+			return v.IsPresent()
+		})
+		awaiters = append(awaiters, func(t testing.TB) readiness {
+			v, ok := w.Await(t)
+			return neighborReadiness(name, v, ok)
+		})
+	}
+	await(t, "ND resolution", "Interface", awaiters)
+}
+
+func neighborReadiness(name string, v *ygnmi.Value[string], ok bool) readiness {
+	if mac, present := v.Val(); ok && present {
+		return readiness{name: name, status: "resolved " + mac, ready: true}
+	}
+	return readiness{name: name, status: "unresolved"}
+}
+
+// WaitForBGPPeers waits until every BGP peer in the config is ESTABLISHED.  It
+// fails the test with the session state of every peer on timeout.
+func WaitForBGPPeers(t testing.TB, otg *otg.OTG, c gosnappi.Config, timeout time.Duration) {
+	t.Helper()
+	var awaiters []awaiter
+	for _, name := range bgpPeers(c) {
+		name := name
+		w := gnmi.Watch(t, otg, gnmi.OTG().BgpPeer(name).SessionState().State(), timeout, func(v *ygnmi.Value[otgtelemetry.E_BgpPeer_SessionState]) bool {
+			state, present := v.Val()
+			return present && state == otgtelemetry.BgpPeer_SessionState_ESTABLISHED
+		})
+		awaiters = append(awaiters, func(t testing.TB) readiness {
+			v, ok := w.Await(t)
+			r := readiness{name: name, status: "not reported", ready: ok}
+			if state, present := v.Val(); present {
+				r.status = state.String()
+			}
+			return r
+		})
+	}
+	await(t, "BGP sessions", "BGP Peer", awaiters)
+}
+
+// WaitForISISRouters waits until every IS-IS router in the config has an
+// adjacency up at level 1 or level 2.  It fails the test with the sessions of
+// every router on timeout.
+func WaitForISISRouters(t testing.TB, otg *otg.OTG, c gosnappi.Config, timeout time.Duration) {
+	t.Helper()
+	var awaiters []awaiter
+	for _, name := range isisRouters(c) {
+		name := name
+		w := gnmi.Watch(t, otg, gnmi.OTG().IsisRouter(name).Counters().State(), timeout, func(v *ygnmi.Value[*otgtelemetry.IsisRouter_Counters]) bool {
+			counters, present := v.Val()
+			return present && isisSessionsUp(counters) > 0
+		})
+		awaiters = append(awaiters, func(t testing.TB) readiness {
+			v, ok := w.Await(t)
+			r := readiness{name: name, status: "not reported", ready: ok}
+			if counters, present := v.Val(); present {
+				r.status = fmt.Sprintf("L1 sessions up %d, L2 sessions up %d",
+					counters.GetLevel1().GetSessionsUp(), counters.GetLevel2().GetSessionsUp())
+			}
+			return r
+		})
+	}
+	await(t, "IS-IS adjacencies", "IS-IS Router", awaiters)
+}
+
+func isisSessionsUp(c *otgtelemetry.IsisRouter_Counters) uint64 {
+	return c.GetLevel1().GetSessionsUp() + c.GetLevel2().GetSessionsUp()
+}
+
+// await waits for all the awaiters, and fails the test with the status table if
+// any of them is not ready.
+func await(t testing.TB, what, kind string, awaiters []awaiter) {
+	t.Helper()
+	var statuses []readiness
+	ready := true
+	for _, a := range awaiters {
+		r := a(t)
+		statuses = append(statuses, r)
+		ready = ready && r.ready
+	}
+	if !ready {
+		t.Fatalf("Timed out waiting for %s:\n%s", what, readinessTable(kind, statuses))
+	}
+	t.Logf("%s ready for %d %ss", what, len(statuses), strings.ToLower(kind))
+}
+
+// readinessTable formats the status of the interfaces, peers or routers.
+func readinessTable(kind string, statuses []readiness) string {
+	var out strings.Builder
+	fmt.Fprintln(&out, strings.Repeat("-", 80))
+	fmt.Fprintf(&out, "%-25s%-10s%-45s\n", kind, "Ready", "Status")
+	for _, r := range statuses {
+		fmt.Fprintf(&out, "%-25v%-10v%-45v\n", r.name, r.ready, r.status)
+	}
+	fmt.Fprintln(&out, strings.Repeat("-", 80))
+	return out.String()
+}
+
+// ipv4Interfaces returns the Ethernet interfaces of the devices with an IPv4
+// address.
+func ipv4Interfaces(c gosnappi.Config) []string {
+	var names []string
+	for _, d := range c.Devices().Items() {
+		for _, eth := range d.Ethernets().Items() {
+			if len(eth.Ipv4Addresses().Items()) > 0 {
+				names = append(names, eth.Name())
+			}
+		}
+	}
+	return names
+}
+
+// ipv6Interfaces returns the Ethernet interfaces of the devices with an IPv6
+// address.
+func ipv6Interfaces(c gosnappi.Config) []string {
+	var names []string
+	for _, d := range c.Devices().Items() {
+		for _, eth := range d.Ethernets().Items() {
+			if len(eth.Ipv6Addresses().Items()) > 0 {
+				names = append(names, eth.Name())
+			}
+		}
+	}
+	return names
+}
+
+// bgpPeers returns the IPv4 and IPv6 BGP peers of the devices.
+func bgpPeers(c gosnappi.Config) []string {
+	var names []string
+	for _, d := range c.Devices().Items() {
+		if !d.HasBgp() {
+			continue
+		}
+		for _, intf := range d.Bgp().Ipv4Interfaces().Items() {
+			for _, peer := range intf.Peers().Items() {
+				names = append(names, peer.Name())
+			}
+		}
+		for _, intf := range d.Bgp().Ipv6Interfaces().Items() {
+			for _, peer := range intf.Peers().Items() {
+				names = append(names, peer.Name())
+			}
+		}
+	}
+	return names
+}
+
+// isisRouters returns the IS-IS routers of the devices.
+func isisRouters(c gosnappi.Config) []string {
+	var names []string
+	for _, d := range c.Devices().Items() {
+		if d.HasIsis() {
+			names = append(names, d.Isis().Name())
+		}
+	}
+	return names
+}
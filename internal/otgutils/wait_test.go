@@ -0,0 +1,77 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otgutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+)
+
+func testConfig() gosnappi.Config {
+	c := gosnappi.NewConfig()
+
+	d1 := c.Devices().Add().SetName("dev1")
+	eth1 := d1.Ethernets().Add().SetName("port1.Eth").SetMac("02:00:01:01:01:01")
+	eth1.Ipv4Addresses().Add().SetName("port1.IPv4").SetAddress("192.0.2.2").SetGateway("192.0.2.1").SetPrefix(30)
+	eth1.Ipv6Addresses().Add().SetName("port1.IPv6").SetAddress("2001:db8::2").SetGateway("2001:db8::1").SetPrefix(126)
+	bgp := d1.Bgp().SetRouterId("192.0.2.2")
+	bgp.Ipv4Interfaces().Add().SetIpv4Name("port1.IPv4").Peers().Add().SetName("port1.BGP4.peer").SetPeerAddress("192.0.2.1").SetAsNumber(65000)
+	bgp.Ipv6Interfaces().Add().SetIpv6Name("port1.IPv6").Peers().Add().SetName("port1.BGP6.peer").SetPeerAddress("2001:db8::1").SetAsNumber(65000)
+
+	d2 := c.Devices().Add().SetName("dev2")
+	eth2 := d2.Ethernets().Add().SetName("port2.Eth").SetMac("02:00:02:01:01:01")
+	eth2.Ipv4Addresses().Add().SetName("port2.IPv4").SetAddress("192.0.2.6").SetGateway("192.0.2.5").SetPrefix(30)
+	d2.Isis().SetName("port2.ISIS").SetSystemId("640000000001")
+
+	return c
+}
+
+func TestConfigNames(t *testing.T) {
+	c := testConfig()
+	cases := []struct {
+		desc string
+		got  []string
+		want []string
+	}{
+		{"ipv4Interfaces", ipv4Interfaces(c), []string{"port1.Eth", "port2.Eth"}},
+		{"ipv6Interfaces", ipv6Interfaces(c), []string{"port1.Eth"}},
+		{"bgpPeers", bgpPeers(c), []string{"port1.BGP4.peer", "port1.BGP6.peer"}},
+		{"isisRouters", isisRouters(c), []string{"port2.ISIS"}},
+	}
+	for _, c := range cases {
+		if diff := cmp.Diff(c.want, c.got); diff != "" {
+			t.Errorf("%s diff (-want +got):\n%s", c.desc, diff)
+		}
+	}
+}
+
+func TestReadinessTable(t *testing.T) {
+	got := readinessTable("BGP Peer", []readiness{
+		{name: "port1.BGP4.peer", status: "ESTABLISHED", ready: true},
+		{name: "port2.BGP4.peer", status: "ACTIVE"},
+	})
+	for _, want := range []string{
+		"BGP Peer                 Ready     Status",
+		"port1.BGP4.peer          true      ESTABLISHED",
+		"port2.BGP4.peer          false     ACTIVE",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("readinessTable got:\n%s\nwant it to contain %q", got, want)
+		}
+	}
+}
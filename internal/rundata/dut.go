@@ -194,8 +194,8 @@ func (di *DUTInfo) setFromSystem(ctx context.Context, y components.Y) {
 `
 }
 
-// shortVendor canonicalizes full vendor string in short uppercase form, if possible.
-func (di *DUTInfo) shortVendor() string {
+// ShortVendor canonicalizes full vendor string in short uppercase form, if possible.
+func (di *DUTInfo) ShortVendor() string {
 	if di.Vendor == "" {
 		return ""
 	}
@@ -222,8 +222,8 @@ var ciscoRE = regexp.MustCompile(`Cisco (.*?) .*`)
 // jnpRE reduces model string from e.g. "JNP10008 [PTX10008]" to just "PTX10008".
 var jnpRE = regexp.MustCompile(`JNP.* \[(.*)\]`)
 
-// shortModel canonicalizes full model to short form.
-func (di *DUTInfo) shortModel() string {
+// ShortModel canonicalizes full model to short form.
+func (di *DUTInfo) ShortModel() string {
 	if matches := ciscoRE.FindStringSubmatch(di.Model); len(matches) >= 2 {
 		return matches[1]
 	}
@@ -237,11 +237,11 @@ func (di *DUTInfo) shortModel() string {
 func (di *DUTInfo) put(m map[string]string, id string) {
 	if di.Vendor != "" {
 		m[id+".vendor.full"] = di.Vendor
-		m[id+".vendor"] = di.shortVendor()
+		m[id+".vendor"] = di.ShortVendor()
 	}
 	if di.Model != "" {
 		m[id+".model.full"] = di.Model
-		m[id+".model"] = di.shortModel()
+		m[id+".model"] = di.ShortModel()
 	}
 	if di.OSVer != "" {
 		m[id+".os_version"] = di.OSVer
@@ -33,7 +33,7 @@ func TestDUTShortVendor(t *testing.T) {
 	}
 	for _, c := range cases {
 		di := &DUTInfo{Vendor: c.vendor}
-		got := di.shortVendor()
+		got := di.ShortVendor()
 		if got != c.want {
 			t.Errorf("Case %q got %q, want %q", c.vendor, got, c.want)
 		}
@@ -51,7 +51,7 @@ func TestDUTShortModel(t *testing.T) {
 	}
 	for _, c := range cases {
 		di := &DUTInfo{Model: c.model}
-		got := di.shortModel()
+		got := di.ShortModel()
 		if got != c.want {
 			t.Errorf("Case %q got %q, want %q", c.model, got, c.want)
 		}
@@ -0,0 +1,148 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"testing"
+
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+// ateGenerator generates traffic with the ATETopology API.
+type ateGenerator struct {
+	ate   *ondatra.ATEDevice
+	top   *ondatra.ATETopology
+	intfs map[string]*ondatra.Interface
+	flows []*Flow
+	ixnet []*ondatra.Flow
+}
+
+// NewATE returns a generator using the ATETopology API.
+func NewATE(t testing.TB, ate *ondatra.ATEDevice) Generator {
+	return &ateGenerator{
+		ate:   ate,
+		top:   ate.Topology().New(),
+		intfs: make(map[string]*ondatra.Interface),
+	}
+}
+
+func (g *ateGenerator) AddInterface(ap *ondatra.Port, a, peer *attrs.Attributes) {
+	g.intfs[a.Name] = a.AddToATE(g.top, ap, peer)
+}
+
+func (g *ateGenerator) AddFlow(f *Flow) {
+	g.flows = append(g.flows, f)
+}
+
+func (g *ateGenerator) Push(t testing.TB) {
+	t.Helper()
+	g.top.Push(t).StartProtocols(t)
+	g.ixnet = nil
+	for _, f := range g.flows {
+		g.ixnet = append(g.ixnet, g.newFlow(t, f))
+	}
+}
+
+// newFlow returns the ATETopology flow of the flow.
+func (g *ateGenerator) newFlow(t testing.TB, f *Flow) *ondatra.Flow {
+	t.Helper()
+	src, ok := g.intfs[f.Src.Name]
+	if !ok {
+		t.Fatalf("Flow %s: source interface %q was not added", f.Name, f.Src.Name)
+	}
+	dst, ok := g.intfs[f.Dst.Name]
+	if !ok {
+		t.Fatalf("Flow %s: destination interface %q was not added", f.Name, f.Dst.Name)
+	}
+
+	var ip ondatra.Header
+	if f.IPv6 {
+		h := ondatra.NewIPv6Header().WithSrcAddress(f.srcAddr())
+		h.DstAddressRange().WithMin(f.dstAddr()).WithCount(dstCount(f))
+		if f.DSCP != 0 {
+			h.WithDSCP(f.DSCP)
+		}
+		if f.TTL != 0 {
+			h.WithHopLimit(f.TTL)
+		}
+		ip = h
+	} else {
+		h := ondatra.NewIPv4Header().WithSrcAddress(f.srcAddr())
+		h.DstAddressRange().WithMin(f.dstAddr()).WithCount(dstCount(f))
+		if f.DSCP != 0 {
+			h.WithDSCP(f.DSCP)
+		}
+		if f.TTL != 0 {
+			h.WithTTL(f.TTL)
+		}
+		ip = h
+	}
+
+	flow := g.ate.Traffic().NewFlow(f.Name).
+		WithSrcEndpoints(src).
+		WithDstEndpoints(dst).
+		WithHeaders(ondatra.NewEthernetHeader(), ip)
+	if f.FrameSize != 0 {
+		flow.WithFrameSize(f.FrameSize)
+	}
+	switch {
+	case f.FPS != 0:
+		flow.WithFrameRateFPS(f.FPS)
+	case f.RatePct != 0:
+		flow.WithFrameRatePct(f.RatePct)
+	}
+	return flow
+}
+
+func dstCount(f *Flow) uint32 {
+	if f.DstCount == 0 {
+		return 1
+	}
+	return f.DstCount
+}
+
+func (g *ateGenerator) Start(t testing.TB) {
+	t.Helper()
+	g.ate.Traffic().Start(t, g.ixnet...)
+}
+
+func (g *ateGenerator) Stop(t testing.TB) {
+	t.Helper()
+	g.ate.Traffic().Stop(t)
+}
+
+func (g *ateGenerator) FlowCounters(t testing.TB, name string) *Counters {
+	t.Helper()
+	c := gnmi.Get(t, g.ate, gnmi.OC().Flow(name).Counters().State())
+	return &Counters{
+		TxPkts:   c.GetOutPkts(),
+		RxPkts:   c.GetInPkts(),
+		TxOctets: c.GetOutOctets(),
+		RxOctets: c.GetInOctets(),
+	}
+}
+
+func (g *ateGenerator) PortCounters(t testing.TB, ap *ondatra.Port) *Counters {
+	t.Helper()
+	c := gnmi.Get(t, g.ate, gnmi.OC().Interface(ap.Name()).Counters().State())
+	return &Counters{
+		TxPkts:   c.GetOutPkts(),
+		RxPkts:   c.GetInPkts(),
+		TxOctets: c.GetOutOctets(),
+		RxOctets: c.GetInOctets(),
+	}
+}
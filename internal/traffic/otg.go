@@ -0,0 +1,141 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/internal/otgutils"
+	"github.com/openconfig/ondatra"
+	"github.com/openconfig/ondatra/gnmi"
+)
+
+// otgGenerator generates traffic with the OTG API.
+type otgGenerator struct {
+	ate *ondatra.ATEDevice
+	top gosnappi.Config
+}
+
+// NewOTG returns a generator using the OTG API.
+func NewOTG(t testing.TB, ate *ondatra.ATEDevice) Generator {
+	return &otgGenerator{
+		ate: ate,
+		top: ate.OTG().NewConfig(t),
+	}
+}
+
+func (g *otgGenerator) AddInterface(ap *ondatra.Port, a, peer *attrs.Attributes) {
+	a.AddToOTG(g.top, ap, peer)
+}
+
+func (g *otgGenerator) AddFlow(f *Flow) {
+	addOTGFlow(g.top, f)
+}
+
+// addOTGFlow adds the flow to the OTG config.  The interfaces are the devices
+// added by attrs.AddToOTG.
+func addOTGFlow(top gosnappi.Config, f *Flow) {
+	flow := top.Flows().Add().SetName(f.Name)
+	flow.Metrics().SetEnable(true)
+	ipName := ".IPv4"
+	if f.IPv6 {
+		ipName = ".IPv6"
+	}
+	flow.TxRx().Device().
+		SetTxNames([]string{f.Src.Name + ipName}).
+		SetRxNames([]string{f.Dst.Name + ipName})
+	flow.Packet().Add().Ethernet().Src().SetValue(f.Src.MAC)
+
+	if f.IPv6 {
+		h := flow.Packet().Add().Ipv6()
+		h.Src().SetValue(f.srcAddr())
+		h.Dst().Increment().SetStart(f.dstAddr()).SetCount(int32(dstCount(f)))
+		if f.DSCP != 0 {
+			h.TrafficClass().SetValue(int32(f.DSCP) << 2)
+		}
+		if f.TTL != 0 {
+			h.HopLimit().SetValue(int32(f.TTL))
+		}
+	} else {
+		h := flow.Packet().Add().Ipv4()
+		h.Src().SetValue(f.srcAddr())
+		h.Dst().Increment().SetStart(f.dstAddr()).SetCount(int32(dstCount(f)))
+		if f.DSCP != 0 {
+			h.Priority().Dscp().Phb().SetValue(int32(f.DSCP))
+		}
+		if f.TTL != 0 {
+			h.TimeToLive().SetValue(int32(f.TTL))
+		}
+	}
+
+	if f.FrameSize != 0 {
+		flow.Size().SetFixed(int32(f.FrameSize))
+	}
+	switch {
+	case f.FPS != 0:
+		flow.Rate().SetPps(int64(f.FPS))
+	case f.RatePct != 0:
+		flow.Rate().SetPercentage(float32(f.RatePct))
+	}
+}
+
+// neighborTimeout is how long Push waits for the ARP and ND resolution.
+const neighborTimeout = time.Minute
+
+// Push pushes the config, starts the protocols and waits until the neighbors
+// of the interfaces are resolved, so that traffic can be started.
+func (g *otgGenerator) Push(t testing.TB) {
+	t.Helper()
+	otg := g.ate.OTG()
+	otg.PushConfig(t, g.top)
+	otg.StartProtocols(t)
+	otgutils.WaitForARP(t, otg, g.top, neighborTimeout)
+	otgutils.WaitForND(t, otg, g.top, neighborTimeout)
+}
+
+func (g *otgGenerator) Start(t testing.TB) {
+	t.Helper()
+	g.ate.OTG().StartTraffic(t)
+}
+
+func (g *otgGenerator) Stop(t testing.TB) {
+	t.Helper()
+	g.ate.OTG().StopTraffic(t)
+}
+
+func (g *otgGenerator) FlowCounters(t testing.TB, name string) *Counters {
+	t.Helper()
+	c := gnmi.Get(t, g.ate.OTG(), gnmi.OTG().Flow(name).Counters().State())
+	return &Counters{
+		TxPkts:   c.GetOutPkts(),
+		RxPkts:   c.GetInPkts(),
+		TxOctets: c.GetOutOctets(),
+		RxOctets: c.GetInOctets(),
+	}
+}
+
+func (g *otgGenerator) PortCounters(t testing.TB, ap *ondatra.Port) *Counters {
+	t.Helper()
+	c := gnmi.Get(t, g.ate.OTG(), gnmi.OTG().Port(ap.ID()).Counters().State())
+	return &Counters{
+		TxPkts:   c.GetOutFrames(),
+		RxPkts:   c.GetInFrames(),
+		TxOctets: c.GetOutOctets(),
+		RxOctets: c.GetInOctets(),
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package traffic generates IPv4 and IPv6 traffic with an ATE through either
+// the ATETopology API (IxNetwork) or the OTG API, so that a test may be written
+// once for both kinds of ATE.  The API is selected by the binding of the ATE.
+//
+//	g := traffic.New(t, ate)
+//	g.AddInterface(ate.Port(t, "port1"), &ateSrc, &dutSrc)
+//	g.AddInterface(ate.Port(t, "port2"), &ateDst, &dutDst)
+//	g.AddFlow(&traffic.Flow{Name: "v4", Src: &ateSrc, Dst: &ateDst, FPS: 1000})
+//	g.Push(t)
+//	traffic.Run(t, g, 15*time.Second)
+//	if c := g.FlowCounters(t, "v4"); c.LossPct() > 0 {
+//		t.Errorf("Flow v4 loss got %.2f%%, want 0%%", c.LossPct())
+//	}
+package traffic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/attrs"
+	"github.com/openconfig/featureprofiles/topologies/binding"
+	"github.com/openconfig/ondatra"
+)
+
+// Generator is the traffic generator of an ATE.
+type Generator interface {
+	// AddInterface adds an interface with the attributes on the ATE port, whose
+	// gateway is the peer, typically the DUT port.
+	AddInterface(ap *ondatra.Port, a, peer *attrs.Attributes)
+	// AddFlow adds a flow between interfaces added to the generator.
+	AddFlow(f *Flow)
+	// Push configures the ATE and starts the protocols.
+	Push(t testing.TB)
+	// Start starts all the flows.
+	Start(t testing.TB)
+	// Stop stops all the flows.
+	Stop(t testing.TB)
+	// FlowCounters returns the counters of a flow.
+	FlowCounters(t testing.TB, name string) *Counters
+	// PortCounters returns the counters of an ATE port.
+	PortCounters(t testing.TB, ap *ondatra.Port) *Counters
+}
+
+// Flow is an IPv4 or IPv6 flow between two interfaces added to a generator.  The
+// zero value of a field takes the default of the ATE.
+type Flow struct {
+	Name     string
+	Src, Dst *attrs.Attributes
+	IPv6     bool
+
+	SrcAddr  string // Defaults to the address of Src.
+	DstAddr  string // Defaults to the address of Dst.
+	DstCount uint32 // Number of destination addresses, incremented from DstAddr.
+	DSCP     uint8
+	TTL      uint8 // Hop limit for IPv6.
+
+	FrameSize uint32
+	FPS       uint64  // Frames per second.
+	RatePct   float64 // Percent of the line rate, if FPS is not set.
+}
+
+// srcAddr returns the source address of the flow.
+func (f *Flow) srcAddr() string {
+	switch {
+	case f.SrcAddr != "":
+		return f.SrcAddr
+	case f.IPv6:
+		return f.Src.IPv6
+	default:
+		return f.Src.IPv4
+	}
+}
+
+// dstAddr returns the first destination address of the flow.
+func (f *Flow) dstAddr() string {
+	switch {
+	case f.DstAddr != "":
+		return f.DstAddr
+	case f.IPv6:
+		return f.Dst.IPv6
+	default:
+		return f.Dst.IPv4
+	}
+}
+
+// Counters are the packet counters of a flow or port.
+type Counters struct {
+	TxPkts, RxPkts     uint64
+	TxOctets, RxOctets uint64
+}
+
+// LossPct returns the percentage of the packets sent which were not received, or
+// 100 if no packet was sent.
+func (c *Counters) LossPct() float64 {
+	if c.TxPkts == 0 {
+		return 100
+	}
+	return (float64(c.TxPkts) - float64(c.RxPkts)) * 100 / float64(c.TxPkts)
+}
+
+// New returns the generator of the ATE, using the OTG API if the binding of the
+// ATE has it, or the ATETopology API otherwise.
+func New(t testing.TB, ate *ondatra.ATEDevice) Generator {
+	t.Helper()
+	if binding.UsesOTG(ate.Name()) {
+		return NewOTG(t, ate)
+	}
+	return NewATE(t, ate)
+}
+
+// Run runs the traffic for the duration.
+func Run(t testing.TB, g Generator, d time.Duration) {
+	t.Helper()
+	g.Start(t)
+	time.Sleep(d)
+	g.Stop(t)
+}
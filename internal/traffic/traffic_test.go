@@ -0,0 +1,172 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"github.com/openconfig/featureprofiles/internal/attrs"
+)
+
+var (
+	ateSrc = attrs.Attributes{
+		Name:    "ateSrc",
+		MAC:     "02:00:01:01:01:01",
+		IPv4:    "192.0.2.2",
+		IPv6:    "2001:db8::192:0:2:2",
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+	ateDst = attrs.Attributes{
+		Name:    "ateDst",
+		MAC:     "02:00:02:01:01:01",
+		IPv4:    "192.0.2.6",
+		IPv6:    "2001:db8::192:0:2:6",
+		IPv4Len: 30,
+		IPv6Len: 126,
+	}
+)
+
+func TestFlowAddresses(t *testing.T) {
+	cases := []struct {
+		desc             string
+		flow             *Flow
+		wantSrc, wantDst string
+	}{{
+		desc:    "ipv4 defaults",
+		flow:    &Flow{Src: &ateSrc, Dst: &ateDst},
+		wantSrc: "192.0.2.2",
+		wantDst: "192.0.2.6",
+	}, {
+		desc:    "ipv6 defaults",
+		flow:    &Flow{Src: &ateSrc, Dst: &ateDst, IPv6: true},
+		wantSrc: "2001:db8::192:0:2:2",
+		wantDst: "2001:db8::192:0:2:6",
+	}, {
+		desc:    "explicit",
+		flow:    &Flow{Src: &ateSrc, Dst: &ateDst, SrcAddr: "198.51.100.1", DstAddr: "203.0.113.1"},
+		wantSrc: "198.51.100.1",
+		wantDst: "203.0.113.1",
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := c.flow.srcAddr(); got != c.wantSrc {
+				t.Errorf("srcAddr got %q, want %q", got, c.wantSrc)
+			}
+			if got := c.flow.dstAddr(); got != c.wantDst {
+				t.Errorf("dstAddr got %q, want %q", got, c.wantDst)
+			}
+		})
+	}
+}
+
+func TestAddOTGFlow(t *testing.T) {
+	top := gosnappi.NewConfig()
+	addOTGFlow(top, &Flow{
+		Name:      "v4",
+		Src:       &ateSrc,
+		Dst:       &ateDst,
+		DstAddr:   "203.0.113.1",
+		DstCount:  100,
+		DSCP:      46,
+		TTL:       10,
+		FrameSize: 512,
+		FPS:       1000,
+	})
+	addOTGFlow(top, &Flow{
+		Name:    "v6",
+		Src:     &ateSrc,
+		Dst:     &ateDst,
+		IPv6:    true,
+		DSCP:    8,
+		RatePct: 10,
+	})
+
+	flows := top.Flows().Items()
+	if len(flows) != 2 {
+		t.Fatalf("addOTGFlow got %d flows, want 2", len(flows))
+	}
+
+	v4 := flows[0]
+	if diff := cmp.Diff([]string{"ateSrc.IPv4"}, v4.TxRx().Device().TxNames()); diff != "" {
+		t.Errorf("v4 tx names diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"ateDst.IPv4"}, v4.TxRx().Device().RxNames()); diff != "" {
+		t.Errorf("v4 rx names diff (-want +got):\n%s", diff)
+	}
+	headers := v4.Packet().Items()
+	if len(headers) != 2 {
+		t.Fatalf("v4 got %d headers, want 2", len(headers))
+	}
+	if got, want := headers[0].Ethernet().Src().Value(), ateSrc.MAC; got != want {
+		t.Errorf("v4 ethernet src got %q, want %q", got, want)
+	}
+	ip := headers[1].Ipv4()
+	if got, want := ip.Src().Value(), ateSrc.IPv4; got != want {
+		t.Errorf("v4 src got %q, want %q", got, want)
+	}
+	if got, want := ip.Dst().Increment().Start(), "203.0.113.1"; got != want {
+		t.Errorf("v4 dst start got %q, want %q", got, want)
+	}
+	if got, want := ip.Dst().Increment().Count(), int32(100); got != want {
+		t.Errorf("v4 dst count got %d, want %d", got, want)
+	}
+	if got, want := ip.Priority().Dscp().Phb().Value(), int32(46); got != want {
+		t.Errorf("v4 dscp got %d, want %d", got, want)
+	}
+	if got, want := ip.TimeToLive().Value(), int32(10); got != want {
+		t.Errorf("v4 ttl got %d, want %d", got, want)
+	}
+	if got, want := v4.Size().Fixed(), int32(512); got != want {
+		t.Errorf("v4 frame size got %d, want %d", got, want)
+	}
+	if got, want := v4.Rate().Pps(), int64(1000); got != want {
+		t.Errorf("v4 pps got %d, want %d", got, want)
+	}
+
+	v6 := flows[1]
+	if diff := cmp.Diff([]string{"ateSrc.IPv6"}, v6.TxRx().Device().TxNames()); diff != "" {
+		t.Errorf("v6 tx names diff (-want +got):\n%s", diff)
+	}
+	ip6 := v6.Packet().Items()[1].Ipv6()
+	if got, want := ip6.Dst().Increment().Start(), ateDst.IPv6; got != want {
+		t.Errorf("v6 dst start got %q, want %q", got, want)
+	}
+	if got, want := ip6.TrafficClass().Value(), int32(32); got != want {
+		t.Errorf("v6 traffic class got %d, want %d", got, want)
+	}
+	if got, want := v6.Rate().Percentage(), float32(10); got != want {
+		t.Errorf("v6 rate got %v%%, want %v%%", got, want)
+	}
+}
+
+func TestLossPct(t *testing.T) {
+	cases := []struct {
+		counters *Counters
+		want     float64
+	}{
+		{&Counters{TxPkts: 1000, RxPkts: 1000}, 0},
+		{&Counters{TxPkts: 1000, RxPkts: 900}, 10},
+		{&Counters{TxPkts: 1000}, 100},
+		{&Counters{}, 100},
+	}
+	for _, c := range cases {
+		if got := c.counters.LossPct(); got != c.want {
+			t.Errorf("LossPct of %+v got %v, want %v", c.counters, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The bindingcheck command validates a static binding against a testbed offline,
+// the same way the binding resolves a reservation, without dialing any device.
+// It prints the resolved dial target and options of each device and protocol,
+// with the secrets redacted, followed by any problem found.
+//
+// Usage:
+//
+//	go run ./tools/bindingcheck --testbed=topologies/atedut_2.testbed --binding=lab.binding [--credentials=lab.credentials]
+//
+// The flags are the same as those of a test, and the port flags of the binding,
+// e.g. --gnmi_port, are honored.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/golang/glog"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openconfig/featureprofiles/topologies/binding"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+// The --testbed, --binding and --credentials flags are registered by Ondatra
+// and by the binding package, which are imported.
+const (
+	testbedFlag     = "testbed"
+	bindingFlag     = "binding"
+	credentialsFlag = "credentials"
+)
+
+func main() {
+	flag.Parse()
+	testbedFile := flag.Lookup(testbedFlag).Value.String()
+	bindingFile := flag.Lookup(bindingFlag).Value.String()
+	if testbedFile == "" || bindingFile == "" {
+		glog.Exitf("Both --%s and --%s must be given.", testbedFlag, bindingFlag)
+	}
+
+	tb := &opb.Testbed{}
+	if err := readProto(testbedFile, tb); err != nil {
+		glog.Exitf("Unable to read testbed: %v", err)
+	}
+	b, err := binding.ReadBinding(bindingFile, flag.Lookup(credentialsFlag).Value.String())
+	if err != nil {
+		glog.Exitf("Unable to read binding: %v", err)
+	}
+
+	targets, err := binding.Check(tb, b)
+	if werr := writeTargets(os.Stdout, targets); werr != nil {
+		glog.Exitf("Unable to write targets: %v", werr)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nThe binding has problems: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readProto reads a text proto file.
+func readProto(file string, m proto.Message) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if err := prototext.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", file, err)
+	}
+	return nil
+}
+
+// writeTargets writes the targets as a table, with the options other than the
+// target as a single-line text proto.
+func writeTargets(w io.Writer, targets []*binding.Target) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPROTOCOL\tTARGET\tOPTIONS")
+	for _, t := range targets {
+		opts := proto.Clone(t.Options).(*bindpb.Options)
+		opts.Target = ""
+		text, err := prototext.MarshalOptions{}.Marshal(opts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", t.ID, t.Name, t.Protocol, t.Options.GetTarget(), text)
+	}
+	return tw.Flush()
+}
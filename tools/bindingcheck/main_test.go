@@ -0,0 +1,55 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openconfig/featureprofiles/topologies/binding"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+)
+
+func TestWriteTargets(t *testing.T) {
+	targets := []*binding.Target{{
+		ID:       "dut",
+		Name:     "dut.name",
+		Protocol: "gnmi",
+		Options: &bindpb.Options{
+			Target:   "dut.name:9339",
+			Username: binding.Redacted,
+			Password: binding.Redacted,
+		},
+	}}
+	var sb strings.Builder
+	if err := writeTargets(&sb, targets); err != nil {
+		t.Fatalf("writeTargets got error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("writeTargets got %d lines, want 2:\n%s", len(lines), sb.String())
+	}
+	if got := strings.Fields(lines[0]); len(got) != 5 || got[0] != "ID" || got[4] != "OPTIONS" {
+		t.Errorf("writeTargets header got %q, want ID NAME PROTOCOL TARGET OPTIONS", lines[0])
+	}
+	for _, want := range []string{"dut.name:9339", `username:"REDACTED"`, `password:"REDACTED"`} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("writeTargets row got %q, want it to contain %q", lines[1], want)
+		}
+	}
+	if strings.Contains(lines[1], "target:") {
+		t.Errorf("writeTargets row got %q, want the target only in its column", lines[1])
+	}
+}
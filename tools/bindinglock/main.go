@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The bindinglock command lists the advisory locks which the static binding
+// takes on the reserved devices in the --lock-dir directory, and breaks them.
+// A lock is stale if it expired, or if the test run holding it on this host is
+// gone; the binding breaks stale locks by itself when it needs them.
+//
+// Usage:
+//
+//	go run ./tools/bindinglock --lock-dir=/shared/locks
+//	go run ./tools/bindinglock --lock-dir=/shared/locks --break_stale
+//	go run ./tools/bindinglock --lock-dir=/shared/locks --break=dut,ate
+//
+// --break breaks the locks of the named devices even if they are held.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/openconfig/featureprofiles/topologies/binding"
+)
+
+// The --lock-dir flag is registered by the binding package, which is imported.
+const lockDirFlag = "lock-dir"
+
+var (
+	breakStale = flag.Bool("break_stale", false, "break all the stale locks")
+	breakLocks = flag.String("break", "", "comma-separated devices whose locks to break, even if held")
+)
+
+func main() {
+	flag.Parse()
+	dir := flag.Lookup(lockDirFlag).Value.String()
+	if dir == "" {
+		glog.Exitf("--%s must be given.", lockDirFlag)
+	}
+
+	var devices []string
+	if *breakLocks != "" {
+		devices = strings.Split(*breakLocks, ",")
+	}
+	if *breakStale {
+		stale, err := staleLocks(dir, time.Now())
+		if err != nil {
+			glog.Exitf("Unable to list locks: %v", err)
+		}
+		devices = append(devices, stale...)
+	}
+	for _, device := range devices {
+		if err := binding.BreakLock(dir, device); err != nil && !errors.Is(err, os.ErrNotExist) {
+			glog.Exitf("Unable to break lock: %v", err)
+		}
+		fmt.Printf("Broke the lock of %s.\n", device)
+	}
+
+	locks, err := binding.ListLocks(dir)
+	if err != nil {
+		glog.Exitf("Unable to list locks: %v", err)
+	}
+	if err := writeLocks(os.Stdout, locks, time.Now()); err != nil {
+		glog.Exitf("Unable to write locks: %v", err)
+	}
+}
+
+// staleLocks returns the devices whose locks are stale.
+func staleLocks(dir string, now time.Time) ([]string, error) {
+	locks, err := binding.ListLocks(dir)
+	if err != nil {
+		return nil, err
+	}
+	var devices []string
+	for _, l := range locks {
+		if l.Stale(now) {
+			devices = append(devices, l.Device)
+		}
+	}
+	return devices, nil
+}
+
+// writeLocks writes the locks as a table.
+func writeLocks(w io.Writer, locks []*binding.Lock, now time.Time) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "DEVICE\tOWNER\tHOST\tPID\tCREATED\tEXPIRES\tSTALE")
+	for _, l := range locks {
+		expires := "never"
+		if !l.Expires.IsZero() {
+			expires = l.Expires.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%t\n",
+			l.Device, l.Owner, l.Host, l.PID, l.Created.Format(time.RFC3339), expires, l.Stale(now))
+	}
+	return tw.Flush()
+}
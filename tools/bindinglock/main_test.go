@@ -0,0 +1,77 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openconfig/featureprofiles/topologies/binding"
+)
+
+func TestStaleLocks(t *testing.T) {
+	now := time.Now()
+	dir := t.TempDir()
+	for _, l := range []*binding.Lock{
+		{Device: "dut1", Host: "otherhost", PID: 1, Expires: now.Add(-time.Minute)},
+		{Device: "dut2", Host: "otherhost", PID: 1, Expires: now.Add(time.Minute)},
+		{Device: "ate1", Host: "otherhost", PID: 1},
+	} {
+		data, err := json.Marshal(l)
+		if err != nil {
+			t.Fatalf("cannot marshal lock: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, l.Device+".lock"), data, 0666); err != nil {
+			t.Fatalf("cannot write lock: %v", err)
+		}
+	}
+	got, err := staleLocks(dir, now)
+	if err != nil {
+		t.Fatalf("staleLocks got error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"dut1"}, got); diff != "" {
+		t.Errorf("staleLocks diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteLocks(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	locks := []*binding.Lock{{
+		Device:  "dut1",
+		Owner:   "alice",
+		Host:    "otherhost",
+		PID:     42,
+		Created: now,
+		Expires: now.Add(-time.Second),
+	}}
+	var sb strings.Builder
+	if err := writeLocks(&sb, locks, now); err != nil {
+		t.Fatalf("writeLocks got error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("writeLocks got %d lines, want 2:\n%s", len(lines), sb.String())
+	}
+	want := []string{"dut1", "alice", "otherhost", "42", "2023-01-02T03:04:05Z", "2023-01-02T03:04:04Z", "true"}
+	if diff := cmp.Diff(want, strings.Fields(lines[1])); diff != "" {
+		t.Errorf("writeLocks row diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The deviationreport command lists the registered deviations that have no open issue,
+// are not used by any test, or are older than a threshold.
+//
+// Usage:
+//
+//	go run ./tools/deviationreport [--max_age_days=180] [--format=table|json] [--all]
+//	go run ./tools/deviationreport --check_issues --github_token=$GITHUB_TOKEN
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+)
+
+var (
+	root        = flag.String("root", "", "Root of the featureprofiles repo.  If not set, it is detected from the source location of this command.")
+	maxAgeDays  = flag.Int("max_age_days", 180, "Report deviations added more than this many days ago.  Zero disables the check.")
+	format      = flag.String("format", "table", "Output format, either table or json.")
+	all         = flag.Bool("all", false, "List all deviations, not just those with problems.")
+	checkIssues = flag.Bool("check_issues", false, "Look up the GitHub issue state; an issue that is not open is reported.")
+	githubToken = flag.String("github_token", os.Getenv("GITHUB_TOKEN"), "GitHub API token used with --check_issues.")
+)
+
+// repoRoot locates the featureprofiles repo from the source location of this command.
+func repoRoot() (string, error) {
+	_, path, _, ok := runtime.Caller(0)
+	if !ok {
+		return "", errors.New("could not detect caller")
+	}
+	newpath := filepath.Dir(path)
+	for newpath != "." && newpath != "/" {
+		if isDir(filepath.Join(newpath, "feature")) && isDir(filepath.Join(newpath, "internal", "deviations")) {
+			return newpath, nil
+		}
+		newpath = filepath.Dir(newpath)
+	}
+	return "", fmt.Errorf("repo root not found from %s", path)
+}
+
+func main() {
+	flag.Parse()
+
+	dir := *root
+	if dir == "" {
+		var err error
+		if dir, err = repoRoot(); err != nil {
+			glog.Exitf("Unable to locate repo root: %v", err)
+		}
+	}
+
+	r := &reporter{
+		root:   dir,
+		now:    time.Now(),
+		maxAge: time.Duration(*maxAgeDays) * 24 * time.Hour,
+	}
+	if *checkIssues {
+		r.issues = &issueChecker{
+			client:  &http.Client{Timeout: 30 * time.Second},
+			baseURL: "https://api.github.com",
+			token:   *githubToken,
+		}
+	}
+
+	entries, err := r.report(context.Background(), deviations.Registry())
+	if err != nil {
+		glog.Exitf("Unable to build report: %v", err)
+	}
+	if !*all {
+		var filtered []*entry
+		for _, e := range entries {
+			if len(e.Problems) > 0 {
+				filtered = append(filtered, e)
+			}
+		}
+		entries = filtered
+	}
+
+	switch *format {
+	case "table":
+		err = writeTable(os.Stdout, entries)
+	case "json":
+		err = writeJSON(os.Stdout, entries)
+	default:
+		glog.Exitf("Unknown --format %q, want table or json.", *format)
+	}
+	if err != nil {
+		glog.Exitf("Unable to write report: %v", err)
+	}
+}
@@ -0,0 +1,515 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+)
+
+const deviationsPkg = "github.com/openconfig/featureprofiles/internal/deviations"
+
+// Problems reported for a deviation.
+const (
+	problemNoIssue    = "no open issue"
+	problemNoMetadata = "missing metadata"
+	problemUnused     = "unused"
+	problemOld        = "old"
+)
+
+// entry is a row of the deviation report.
+type entry struct {
+	Flag       string   `json:"flag"`
+	Var        string   `json:"var,omitempty"`
+	IssueURL   string   `json:"issue_url,omitempty"`
+	IssueState string   `json:"issue_state,omitempty"`
+	Vendor     string   `json:"vendor,omitempty"`
+	Added      string   `json:"added,omitempty"`
+	AgeDays    int      `json:"age_days,omitempty"`
+	Features   []string `json:"features,omitempty"`
+	Uses       []string `json:"uses,omitempty"`
+	Problems   []string `json:"problems,omitempty"`
+}
+
+// flagVars maps the deviation flag names to the Go variable names by parsing the
+// deviations package source in dir.
+func flagVars(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	vars := make(map[string]string)
+	for _, pkg := range pkgs {
+		ast.Inspect(pkg, func(n ast.Node) bool {
+			vs, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, v := range vs.Values {
+				call, ok := v.(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 || i >= len(vs.Names) {
+					continue
+				}
+				lit, ok := call.Args[0].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				name, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					continue
+				}
+				vars[name] = vs.Names[i].Name
+			}
+			return false
+		})
+	}
+	return vars, nil
+}
+
+// methodFlags maps the methods of deviations.Deviations to the deviation flag names
+// they resolve, by parsing the deviations package source in dir.  The flag of a method
+// is the first deviation flag name in its body, e.g. "deviation_interface_enabled" in
+// d.boolValue("deviation_interface_enabled", *InterfaceEnabled).
+func methodFlags(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	flags := make(map[string]string)
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil || !isDeviationsType(fn.Recv, "") {
+					continue
+				}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					lit, ok := n.(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						return true
+					}
+					if name, err := strconv.Unquote(lit.Value); err == nil && strings.HasPrefix(name, "deviation_") {
+						if _, ok := flags[fn.Name.Name]; !ok {
+							flags[fn.Name.Name] = name
+						}
+					}
+					return false
+				})
+			}
+		}
+	}
+	return flags, nil
+}
+
+// isDeviationsType returns true if the field list is a single *Deviations receiver,
+// or *<local>.Deviations if local is not empty.
+func isDeviationsType(fl *ast.FieldList, local string) bool {
+	if fl == nil || len(fl.List) != 1 {
+		return false
+	}
+	return isDeviationsPtr(fl.List[0].Type, local)
+}
+
+// isDeviationsPtr returns true if the type expression is *Deviations, or
+// *<local>.Deviations if local is not empty.
+func isDeviationsPtr(expr ast.Expr, local string) bool {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	if local == "" {
+		id, ok := star.X.(*ast.Ident)
+		return ok && id.Name == "Deviations"
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name == local && sel.Sel.Name == "Deviations"
+}
+
+// methodPrefix marks the uses of a method of deviations.Deviations in the
+// identifiers returned by fileUses, e.g. "(*Deviations).InterfaceEnabled".
+const methodPrefix = "(*Deviations)."
+
+// uses finds the Go packages under root that reference the exported identifiers of the
+// deviations package, or the methods of deviations.Deviations.  It returns a map from
+// the identifier to the package directories relative to root.
+func uses(root string) (map[string][]string, error) {
+	found := make(map[string]map[string]bool)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if filepath.ToSlash(rel) == "internal/deviations" {
+			return nil
+		}
+		idents, err := fileUses(path)
+		if err != nil {
+			return err
+		}
+		for _, ident := range idents {
+			if found[ident] == nil {
+				found[ident] = make(map[string]bool)
+			}
+			found[ident][filepath.ToSlash(rel)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]string)
+	for ident, dirs := range found {
+		for dir := range dirs {
+			result[ident] = append(result[ident], dir)
+		}
+		sort.Strings(result[ident])
+	}
+	return result, nil
+}
+
+// fileUses returns the identifiers of the deviations package referenced by a Go file.
+// The methods called on a *deviations.Deviations, e.g. deviations.For(dut).X() or
+// d.X() where d holds the result of For or is declared as *deviations.Deviations, are
+// returned with methodPrefix.
+func fileUses(path string) ([]string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	var local string
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p != deviationsPkg {
+			continue
+		}
+		local = "deviations"
+		if imp.Name != nil {
+			local = imp.Name.Name
+		}
+	}
+	if local == "" || local == "_" {
+		return nil, nil
+	}
+
+	isFor := func(expr ast.Expr) bool {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		x, ok := sel.X.(*ast.Ident)
+		return ok && x.Name == local && sel.Sel.Name == "For"
+	}
+	// devNames are the variables, parameters, and fields of type *deviations.Deviations.
+	devNames := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range n.Rhs {
+				if id, ok := n.Lhs[i].(*ast.Ident); ok && isFor(rhs) {
+					devNames[id.Name] = true
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if isDeviationsPtr(n.Type, local) || (i < len(n.Values) && isFor(n.Values[i])) {
+					devNames[name.Name] = true
+				}
+			}
+		case *ast.Field:
+			if isDeviationsPtr(n.Type, local) {
+				for _, name := range n.Names {
+					devNames[name.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	isDeviations := func(expr ast.Expr) bool {
+		switch x := expr.(type) {
+		case *ast.CallExpr:
+			return isFor(x)
+		case *ast.Ident:
+			return devNames[x.Name]
+		case *ast.SelectorExpr:
+			return devNames[x.Sel.Name]
+		}
+		return false
+	}
+
+	var idents []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == local {
+			idents = append(idents, sel.Sel.Name)
+		} else if isDeviations(sel.X) {
+			idents = append(idents, methodPrefix+sel.Sel.Name)
+		}
+		return true
+	})
+	return idents, nil
+}
+
+// testDirs are the directories of a feature profile that hold its tests.
+var testDirs = map[string]bool{"ate_tests": true, "otg_tests": true, "kne_tests": true, "tests": true}
+
+// features returns the feature profiles of the package directories that use a
+// deviation, as paths relative to the feature directory, e.g. "interface/aggregate".
+func features(dirs []string) []string {
+	found := make(map[string]bool)
+	for _, dir := range dirs {
+		if !strings.HasPrefix(dir, "feature/") {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(dir, "feature/"), "/")
+		for i, part := range parts {
+			if testDirs[part] {
+				parts = parts[:i]
+				break
+			}
+		}
+		if len(parts) > 0 {
+			found[strings.Join(parts, "/")] = true
+		}
+	}
+	var result []string
+	for f := range found {
+		result = append(result, f)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// issueRE matches a GitHub issue URL.
+var issueRE = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+)/issues/(\d+)$`)
+
+// issueChecker looks up the state of GitHub issues.
+type issueChecker struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// state returns the state of the issue, either "open" or "closed".
+func (ic *issueChecker) state(ctx context.Context, issueURL string) (string, error) {
+	m := issueRE.FindStringSubmatch(issueURL)
+	if m == nil {
+		return "", fmt.Errorf("not a GitHub issue URL: %s", issueURL)
+	}
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/issues/%s", ic.baseURL, m[1], m[2], m[3])
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if ic.token != "" {
+		req.Header.Set("Authorization", "Bearer "+ic.token)
+	}
+	resp, err := ic.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", apiURL, resp.Status)
+	}
+	var issue struct {
+		State string `json:"state"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return "", fmt.Errorf("GET %s: %w", apiURL, err)
+	}
+	return issue.State, nil
+}
+
+// reporter builds the deviation report.
+type reporter struct {
+	root   string        // Root of the featureprofiles repo.
+	now    time.Time     // Reference time for the age of deviations.
+	maxAge time.Duration // Deviations older than this are reported.
+	issues *issueChecker // Optional; if nil, issue state is not checked.
+}
+
+// report returns one entry per registered deviation.
+func (r *reporter) report(ctx context.Context, devs []deviations.Deviation) ([]*entry, error) {
+	vars, err := flagVars(filepath.Join(r.root, "internal", "deviations"))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse deviations: %w", err)
+	}
+	methods, err := methodFlags(filepath.Join(r.root, "internal", "deviations"))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse deviations: %w", err)
+	}
+	used, err := uses(r.root)
+	if err != nil {
+		return nil, fmt.Errorf("could not find uses of deviations: %w", err)
+	}
+	// flagUses are the package directories using each flag, either directly or
+	// through a method of deviations.Deviations.
+	flagUses := make(map[string]map[string]bool)
+	addUses := func(name string, dirs []string) {
+		if flagUses[name] == nil {
+			flagUses[name] = make(map[string]bool)
+		}
+		for _, dir := range dirs {
+			flagUses[name][dir] = true
+		}
+	}
+	for name, v := range vars {
+		addUses(name, used[v])
+	}
+	for method, name := range methods {
+		addUses(name, used[methodPrefix+method])
+	}
+
+	var entries []*entry
+	for _, dev := range devs {
+		e := &entry{
+			Flag:     dev.Name,
+			Var:      vars[dev.Name],
+			IssueURL: dev.IssueURL,
+			Vendor:   dev.Vendor,
+			Added:    dev.Added,
+		}
+		for dir := range flagUses[dev.Name] {
+			e.Uses = append(e.Uses, dir)
+		}
+		sort.Strings(e.Uses)
+		e.Features = features(e.Uses)
+
+		if e.IssueURL == "" || e.Vendor == "" || e.Added == "" {
+			e.Problems = append(e.Problems, problemNoMetadata)
+		}
+		if e.IssueURL != "" && r.issues != nil {
+			state, err := r.issues.state(ctx, e.IssueURL)
+			if err != nil {
+				return nil, fmt.Errorf("could not check issue for %s: %w", e.Flag, err)
+			}
+			e.IssueState = state
+			if state != "open" {
+				e.Problems = append(e.Problems, problemNoIssue)
+			}
+		}
+
+		if len(e.Uses) == 0 {
+			e.Problems = append(e.Problems, problemUnused)
+		}
+
+		added, err := dev.AddedTime()
+		if err != nil {
+			return nil, fmt.Errorf("bad date for %s: %w", e.Flag, err)
+		}
+		if !added.IsZero() {
+			age := r.now.Sub(added)
+			e.AgeDays = int(age.Hours() / 24)
+			if r.maxAge > 0 && age > r.maxAge {
+				e.Problems = append(e.Problems, problemOld)
+			}
+		}
+
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// writeTable writes the entries as a table.
+func writeTable(w io.Writer, entries []*entry) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "FLAG\tVENDOR\tADDED\tAGE\tISSUE\tUSES\tPROBLEMS")
+	for _, e := range entries {
+		age := "-"
+		if e.Added != "" {
+			age = fmt.Sprintf("%dd", e.AgeDays)
+		}
+		issue := e.IssueURL
+		if e.IssueState != "" {
+			issue += " (" + e.IssueState + ")"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			e.Flag, dash(e.Vendor), dash(e.Added), age, dash(issue), len(e.Uses), dash(strings.Join(e.Problems, ", ")))
+	}
+	return tw.Flush()
+}
+
+// writeJSON writes the entries as a JSON array.
+func writeJSON(w io.Writer, entries []*entry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if entries == nil {
+		entries = []*entry{}
+	}
+	return enc.Encode(entries)
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// isDir returns true if the path is a directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
@@ -0,0 +1,238 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+)
+
+// writeRepo creates a fake repo with the given files relative to the root.
+func writeRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+var fakeRepo = map[string]string{
+	"internal/deviations/deviations.go": `package deviations
+
+import "flag"
+
+var (
+	Used = flag.Bool("deviation_used", false, "used")
+
+	Unused = flag.Bool("deviation_unused", false, "unused")
+
+	Aliased = flag.String("deviation_aliased", "", "aliased")
+
+	ByMethod = flag.Bool("deviation_by_method", false, "by method")
+)
+`,
+	"internal/deviations/device.go": `package deviations
+
+type Deviations struct{}
+
+func For(d interface{}) *Deviations { return &Deviations{} }
+
+func (d *Deviations) boolValue(name string, global bool) bool { return global }
+
+func (d *Deviations) ByMethod() bool {
+	return d.boolValue("deviation_by_method", *ByMethod)
+}
+
+func (d *Deviations) Unused() bool {
+	return d.boolValue("deviation_unused", *Unused)
+}
+`,
+	"feature/baz/otg_tests/baz_test/baz_test.go": `package baz_test
+
+import "github.com/openconfig/featureprofiles/internal/deviations"
+
+var _ = deviations.For(nil).ByMethod()
+`,
+	"internal/qux/qux.go": `package qux
+
+import "github.com/openconfig/featureprofiles/internal/deviations"
+
+func f(d *deviations.Deviations) bool {
+	return d.ByMethod()
+}
+
+func g() bool {
+	devs := deviations.For(nil)
+	return devs.ByMethod()
+}
+`,
+	"feature/foo/tests/foo_test/foo_test.go": `package foo_test
+
+import "github.com/openconfig/featureprofiles/internal/deviations"
+
+var _ = *deviations.Used
+`,
+	"internal/bar/bar.go": `package bar
+
+import dev "github.com/openconfig/featureprofiles/internal/deviations"
+
+var _ = *dev.Aliased + *dev.Aliased
+`,
+}
+
+func TestReport(t *testing.T) {
+	root := writeRepo(t, fakeRepo)
+
+	issues := map[string]string{
+		"/repos/openconfig/featureprofiles/issues/1": `{"state": "open"}`,
+		"/repos/openconfig/featureprofiles/issues/2": `{"state": "closed"}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := issues[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	now := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	r := &reporter{
+		root:   root,
+		now:    now,
+		maxAge: 30 * 24 * time.Hour,
+		issues: &issueChecker{client: srv.Client(), baseURL: srv.URL},
+	}
+	devs := []deviations.Deviation{{
+		Flag: &flag.Flag{Name: "deviation_aliased"},
+		Metadata: deviations.Metadata{
+			IssueURL: "https://github.com/openconfig/featureprofiles/issues/1",
+			Vendor:   "ACME",
+			Added:    "2023-01-21",
+		},
+	}, {
+		Flag: &flag.Flag{Name: "deviation_unused"},
+		Metadata: deviations.Metadata{
+			IssueURL: "https://github.com/openconfig/featureprofiles/issues/2",
+			Vendor:   "ACME",
+			Added:    "2022-01-31",
+		},
+	}, {
+		Flag: &flag.Flag{Name: "deviation_by_method"},
+	}, {
+		Flag: &flag.Flag{Name: "deviation_used"},
+	}}
+
+	got, err := r.report(context.Background(), devs)
+	if err != nil {
+		t.Fatalf("report got error: %v", err)
+	}
+	want := []*entry{{
+		Flag:       "deviation_aliased",
+		Var:        "Aliased",
+		IssueURL:   "https://github.com/openconfig/featureprofiles/issues/1",
+		IssueState: "open",
+		Vendor:     "ACME",
+		Added:      "2023-01-21",
+		AgeDays:    10,
+		Uses:       []string{"internal/bar"},
+	}, {
+		Flag:       "deviation_unused",
+		Var:        "Unused",
+		IssueURL:   "https://github.com/openconfig/featureprofiles/issues/2",
+		IssueState: "closed",
+		Vendor:     "ACME",
+		Added:      "2022-01-31",
+		AgeDays:    365,
+		Problems:   []string{problemNoIssue, problemUnused, problemOld},
+	}, {
+		Flag:     "deviation_by_method",
+		Var:      "ByMethod",
+		Features: []string{"baz"},
+		Uses:     []string{"feature/baz/otg_tests/baz_test", "internal/qux"},
+		Problems: []string{problemNoMetadata},
+	}, {
+		Flag:     "deviation_used",
+		Var:      "Used",
+		Features: []string{"foo"},
+		Uses:     []string{"feature/foo/tests/foo_test"},
+		Problems: []string{problemNoMetadata},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("report diff (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, got); err != nil {
+		t.Fatalf("writeTable got error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 5 {
+		t.Errorf("writeTable got %d lines, want 5:\n%s", len(lines), buf.String())
+	}
+
+	buf.Reset()
+	if err := writeJSON(&buf, got); err != nil {
+		t.Fatalf("writeJSON got error: %v", err)
+	}
+	var decoded []*entry
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("writeJSON produced bad JSON: %v", err)
+	}
+	if diff := cmp.Diff(want, decoded); diff != "" {
+		t.Errorf("writeJSON round trip diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestFeatures(t *testing.T) {
+	got := features([]string{
+		"feature/bgp/policybase/ate_tests/route_installation_test",
+		"feature/bgp/policybase/otg_tests/route_installation_test",
+		"feature/gnoi/system/tests/ping_test",
+		"feature/experimental/isis/otg_tests/internal/session",
+		"internal/fptest",
+	})
+	want := []string{"bgp/policybase", "experimental/isis", "gnoi/system"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("features diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestIssueChecker_BadURL(t *testing.T) {
+	ic := &issueChecker{client: http.DefaultClient, baseURL: "http://invalid"}
+	if _, err := ic.state(context.Background(), "https://example.com/issues/1"); err == nil {
+		t.Error("state got no error for a non-GitHub URL")
+	}
+}
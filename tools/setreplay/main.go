@@ -0,0 +1,134 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The setreplay command replays the gNMI SetRequests of a set audit, as
+// recorded by fptest, against a device and reports the RPCs whose status
+// differs from the recorded one.
+//
+// Usage:
+//
+//	go run ./tools/setreplay --audit=set_audit.textproto --dry_run
+//	go run ./tools/setreplay --audit=set_audit.textproto --target=dut:9339 \
+//	  --username=admin --password=admin --skip_verify [--test=TestFoo] [--device=dut]
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	sapb "github.com/openconfig/featureprofiles/proto/set_audit_go_proto"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var (
+	auditFile  = flag.String("audit", "", "Set audit textproto written by fptest.")
+	target     = flag.String("target", "", "gNMI target as host:port.  Required unless --dry_run.")
+	username   = flag.String("username", "", "Username sent in the RPC metadata.")
+	password   = flag.String("password", "", "Password sent in the RPC metadata.")
+	plaintext  = flag.Bool("insecure", false, "Dial without TLS.")
+	skipVerify = flag.Bool("skip_verify", false, "Dial with TLS but skip the verification of the target certificate.")
+	testRE     = flag.String("test", "", "Only replay the entries whose test matches this regular expression.")
+	device     = flag.String("device", "", "Only replay the entries sent to this device.")
+	dryRun     = flag.Bool("dry_run", false, "Print the SetRequests instead of sending them.")
+	keepGoing  = flag.Bool("keep_going", false, "Keep replaying after an RPC whose status differs from the recorded one.")
+)
+
+// creds sends the username and password in the RPC metadata, like the
+// static binding does.
+type creds struct {
+	username, password string
+	secure             bool
+}
+
+func (c *creds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"username": c.username,
+		"password": c.password,
+	}, nil
+}
+
+func (c *creds) RequireTransportSecurity() bool {
+	return c.secure
+}
+
+func dial(ctx context.Context) (*grpc.ClientConn, error) {
+	var opts []grpc.DialOption
+	switch {
+	case *plaintext:
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	case *skipVerify:
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	default:
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	}
+	if *username != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(&creds{*username, *password, !*plaintext}))
+	}
+	return grpc.DialContext(ctx, *target, opts...)
+}
+
+func main() {
+	flag.Parse()
+
+	if *auditFile == "" {
+		glog.Exit("Missing --audit.")
+	}
+	content, err := os.ReadFile(*auditFile)
+	if err != nil {
+		glog.Exitf("Unable to read the set audit: %v", err)
+	}
+	audit := &sapb.SetAudit{}
+	if err := prototext.Unmarshal(content, audit); err != nil {
+		glog.Exitf("Unable to parse the set audit: %v", err)
+	}
+	f := &filter{device: *device}
+	if *testRE != "" {
+		if f.test, err = regexp.Compile(*testRE); err != nil {
+			glog.Exitf("Invalid --test: %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	r := &replayer{out: os.Stdout, keepGoing: *keepGoing}
+	if !*dryRun {
+		if *target == "" {
+			glog.Exit("Missing --target.")
+		}
+		conn, err := dial(ctx)
+		if err != nil {
+			glog.Exitf("Unable to dial %s: %v", *target, err)
+		}
+		defer conn.Close()
+		r.client = gpb.NewGNMIClient(conn)
+	}
+
+	mismatches, err := r.replay(ctx, audit, f)
+	if err != nil {
+		glog.Exitf("Unable to replay: %v", err)
+	}
+	if mismatches > 0 {
+		fmt.Fprintf(os.Stderr, "%d RPCs did not match the recorded status.\n", mismatches)
+		os.Exit(1)
+	}
+}
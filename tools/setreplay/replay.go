@@ -0,0 +1,92 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	sapb "github.com/openconfig/featureprofiles/proto/set_audit_go_proto"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// filter selects the entries to replay.
+type filter struct {
+	test   *regexp.Regexp
+	device string
+}
+
+func (f *filter) match(e *sapb.SetAudit_Entry) bool {
+	if f.test != nil && !f.test.MatchString(e.GetTest()) {
+		return false
+	}
+	return f.device == "" || f.device == e.GetDevice()
+}
+
+// replayer sends the recorded SetRequests, or only prints them if the
+// client is nil.
+type replayer struct {
+	client    gpb.GNMIClient
+	out       io.Writer
+	keepGoing bool
+}
+
+// replay sends the SetRequests of the selected entries in order and
+// returns the number of RPCs whose status code differs from the
+// recorded one.  Unless keepGoing is set, it stops at the first one.
+func (r *replayer) replay(ctx context.Context, audit *sapb.SetAudit, f *filter) (int, error) {
+	mismatches := 0
+	for i, e := range audit.GetEntries() {
+		if !f.match(e) {
+			continue
+		}
+		req := &gpb.SetRequest{}
+		if err := e.GetRequest().UnmarshalTo(req); err != nil {
+			return mismatches, fmt.Errorf("entry %d: cannot unpack the SetRequest: %w", i, err)
+		}
+		label := fmt.Sprintf("#%d %s %s", i, e.GetTest(), e.GetDevice())
+		if r.client == nil {
+			text, err := prototext.MarshalOptions{Multiline: true}.Marshal(req)
+			if err != nil {
+				return mismatches, fmt.Errorf("entry %d: %w", i, err)
+			}
+			fmt.Fprintf(r.out, "%s:\n%s\n", label, text)
+			continue
+		}
+
+		_, err := r.client.Set(ctx, req)
+		got, want := status.Code(err), codes.Code(e.GetCode())
+		if got == want {
+			fmt.Fprintf(r.out, "%s: OK (%v)\n", label, got)
+			continue
+		}
+		mismatches++
+		fmt.Fprintf(r.out, "%s: MISMATCH got %v, want %v", label, got, want)
+		if err != nil {
+			fmt.Fprintf(r.out, ": %v", err)
+		}
+		fmt.Fprintln(r.out)
+		if !r.keepGoing {
+			break
+		}
+	}
+	return mismatches, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	sapb "github.com/openconfig/featureprofiles/proto/set_audit_go_proto"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// fakeGNMI fails the SetRequests which replace anything.
+type fakeGNMI struct {
+	gpb.GNMIClient
+	sent []*gpb.SetRequest
+}
+
+func (f *fakeGNMI) Set(ctx context.Context, req *gpb.SetRequest, opts ...grpc.CallOption) (*gpb.SetResponse, error) {
+	f.sent = append(f.sent, req)
+	if len(req.GetReplace()) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "replace rejected")
+	}
+	return &gpb.SetResponse{}, nil
+}
+
+func entry(t *testing.T, test, device, elem string, replace bool, code codes.Code) *sapb.SetAudit_Entry {
+	t.Helper()
+	path := &gpb.Path{Elem: []*gpb.PathElem{{Name: elem}}}
+	req := &gpb.SetRequest{Delete: []*gpb.Path{path}}
+	if replace {
+		req = &gpb.SetRequest{Replace: []*gpb.Update{{Path: path}}}
+	}
+	a, err := anypb.New(req)
+	if err != nil {
+		t.Fatalf("anypb.New got error: %v", err)
+	}
+	return &sapb.SetAudit_Entry{Test: test, Device: device, Request: a, Code: int32(code)}
+}
+
+func TestReplay(t *testing.T) {
+	audit := &sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{
+		entry(t, "TestFoo", "dut", "a", false, codes.OK),
+		entry(t, "TestFoo", "dut", "b", true, codes.OK),
+		entry(t, "TestFoo/sub", "dut2", "c", true, codes.InvalidArgument),
+		entry(t, "TestBar", "dut", "d", false, codes.OK),
+	}}
+
+	cases := []struct {
+		desc           string
+		filter         *filter
+		keepGoing      bool
+		wantSent       []string
+		wantMismatches int
+	}{{
+		desc:           "stop at mismatch",
+		filter:         &filter{},
+		wantSent:       []string{"a", "b"},
+		wantMismatches: 1,
+	}, {
+		desc:           "keep going",
+		filter:         &filter{},
+		keepGoing:      true,
+		wantSent:       []string{"a", "b", "c", "d"},
+		wantMismatches: 1,
+	}, {
+		desc:     "test filter",
+		filter:   &filter{test: regexp.MustCompile("^TestFoo/")},
+		wantSent: []string{"c"},
+	}, {
+		desc:     "device filter",
+		filter:   &filter{test: regexp.MustCompile("Bar"), device: "dut"},
+		wantSent: []string{"d"},
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			client := &fakeGNMI{}
+			var out strings.Builder
+			r := &replayer{client: client, out: &out, keepGoing: c.keepGoing}
+			mismatches, err := r.replay(context.Background(), audit, c.filter)
+			if err != nil {
+				t.Fatalf("replay got error: %v", err)
+			}
+			if mismatches != c.wantMismatches {
+				t.Errorf("replay got %d mismatches, want %d; output:\n%s", mismatches, c.wantMismatches, out.String())
+			}
+			var sent []string
+			for _, req := range client.sent {
+				for _, p := range req.GetDelete() {
+					sent = append(sent, p.GetElem()[0].GetName())
+				}
+				for _, u := range req.GetReplace() {
+					sent = append(sent, u.GetPath().GetElem()[0].GetName())
+				}
+			}
+			if diff := cmp.Diff(c.wantSent, sent); diff != "" {
+				t.Errorf("Sent requests diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplay_DryRun(t *testing.T) {
+	audit := &sapb.SetAudit{Entries: []*sapb.SetAudit_Entry{entry(t, "TestFoo", "dut", "a", false, codes.OK)}}
+	var out strings.Builder
+	r := &replayer{out: &out}
+	if _, err := r.replay(context.Background(), audit, &filter{}); err != nil {
+		t.Fatalf("replay got error: %v", err)
+	}
+	if got := out.String(); !strings.Contains(got, "#0 TestFoo dut:") || !strings.Contains(got, `name:`) {
+		t.Errorf("replay dry run got output %q, want the SetRequest", got)
+	}
+}
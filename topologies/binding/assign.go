@@ -0,0 +1,467 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// isPool reports whether the binding has candidate devices or ports, which
+// have no ID.
+func isPool(b *bindpb.Binding) bool {
+	for _, dev := range append(append([]*bindpb.Device{}, b.Duts...), b.Ates...) {
+		if dev.Id == "" {
+			return true
+		}
+		for _, p := range dev.Ports {
+			if p.Id == "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// assign resolves the binding for the testbed and the partial pins of
+// --reserve, which map the testbed device IDs to device names and the
+// "<device ID>:<port ID>" to port names.  A binding that maps the testbed
+// exactly by ID is returned as is, after checking the pins.  Otherwise the
+// testbed devices and ports are assigned to the candidates of the pool, and
+// the returned binding has exactly the testbed devices and ports, with their
+// testbed IDs.
+func assign(tb *opb.Testbed, b *bindpb.Binding, partial map[string]string) (*bindpb.Binding, error) {
+	if err := checkPins(tb, partial); err != nil {
+		return nil, err
+	}
+	if !isPool(b) {
+		if err := checkExactPins(b, partial); err != nil {
+			return nil, err
+		}
+		return b, nil
+	}
+
+	a, err := newAssigner(tb, b, partial)
+	if err != nil {
+		return nil, err
+	}
+	if !a.assignDevice(0) {
+		return nil, fmt.Errorf("no assignment of the binding pool satisfies the testbed: %s", a.failure)
+	}
+	return a.resolved(), nil
+}
+
+// checkPins checks that the pins refer to testbed devices and ports.
+func checkPins(tb *opb.Testbed, partial map[string]string) error {
+	ids := make(map[string]bool)
+	for _, td := range append(append([]*opb.Device{}, tb.Duts...), tb.Ates...) {
+		ids[td.Id] = true
+		for _, tp := range td.Ports {
+			ids[td.Id+":"+tp.Id] = true
+		}
+	}
+	var errs allerrors
+	for id := range partial {
+		if !ids[id] {
+			errs = append(errs, fmt.Errorf("reserve pin %q is not a device or port of the testbed", id))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// checkExactPins checks that the pins agree with a binding which maps the
+// testbed exactly.
+func checkExactPins(b *bindpb.Binding, partial map[string]string) error {
+	var errs allerrors
+	for _, dev := range append(append([]*bindpb.Device{}, b.Duts...), b.Ates...) {
+		if name, ok := partial[dev.Id]; ok && name != dev.Name {
+			errs = append(errs, fmt.Errorf("reserve pin %s=%s, but the binding has %s", dev.Id, name, dev.Name))
+		}
+		for _, p := range dev.Ports {
+			id := dev.Id + ":" + p.Id
+			if name, ok := partial[id]; ok && name != p.Name {
+				errs = append(errs, fmt.Errorf("reserve pin %s=%s, but the binding has %s", id, name, p.Name))
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// tbDevice is a testbed device and whether it is a DUT.
+type tbDevice struct {
+	*opb.Device
+	dut bool
+}
+
+// tbPort is a port of a testbed device.
+type tbPort struct {
+	dev  *tbDevice
+	port *opb.Port
+}
+
+func (p *tbPort) id() string { return p.dev.Id + ":" + p.port.Id }
+
+// assigner searches the assignment of a testbed to a binding pool by
+// backtracking: first the devices, then the ports, in the order of the
+// testbed links so that linked ports are assigned together.
+type assigner struct {
+	b       *bindpb.Binding
+	partial map[string]string
+
+	devices []*tbDevice
+	ports   []*tbPort
+	tbPeer  map[string]string         // Testbed "<device ID>:<port ID>" to its peer.
+	bPeer   map[string]string         // Binding "<device name>:<port name>" to its peer.
+	byName  map[string]*bindpb.Port   // Binding "<device name>:<port name>" to the port.
+	regexps map[string]*regexp.Regexp // Testbed regular expressions of the devices.
+
+	devOf    map[string]*bindpb.Device // Testbed device ID to the binding device.
+	usedDev  map[*bindpb.Device]bool
+	portOf   map[string]*bindpb.Port // Testbed "<device ID>:<port ID>" to the binding port.
+	usedPort map[*bindpb.Port]bool
+
+	depth   int    // Deepest step of the search, for the failure.
+	failure string // Why the deepest step failed.
+}
+
+func newAssigner(tb *opb.Testbed, b *bindpb.Binding, partial map[string]string) (*assigner, error) {
+	a := &assigner{
+		b:        b,
+		partial:  partial,
+		tbPeer:   make(map[string]string),
+		bPeer:    make(map[string]string),
+		byName:   make(map[string]*bindpb.Port),
+		regexps:  make(map[string]*regexp.Regexp),
+		devOf:    make(map[string]*bindpb.Device),
+		usedDev:  make(map[*bindpb.Device]bool),
+		portOf:   make(map[string]*bindpb.Port),
+		usedPort: make(map[*bindpb.Port]bool),
+		depth:    -1,
+	}
+	var errs allerrors
+
+	ports := make(map[string]*tbPort)
+	for _, td := range tb.Duts {
+		a.devices = append(a.devices, &tbDevice{td, true})
+	}
+	for _, td := range tb.Ates {
+		a.devices = append(a.devices, &tbDevice{td, false})
+	}
+	for _, td := range a.devices {
+		for _, tp := range td.Ports {
+			p := &tbPort{td, tp}
+			ports[p.id()] = p
+		}
+		for _, re := range []string{td.GetHardwareModelRegex(), td.GetSoftwareVersionRegex()} {
+			if re == "" {
+				continue
+			}
+			r, err := regexp.Compile(re)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("testbed device %q has an invalid regular expression: %w", td.Id, err))
+				continue
+			}
+			a.regexps[re] = r
+		}
+	}
+	for _, l := range tb.Links {
+		a.tbPeer[l.A] = l.B
+		a.tbPeer[l.B] = l.A
+	}
+
+	// Order the ports so that linked ports are adjacent.
+	added := make(map[string]bool)
+	addPort := func(id string) {
+		if p, ok := ports[id]; ok && !added[id] {
+			a.ports = append(a.ports, p)
+			added[id] = true
+		}
+	}
+	for _, l := range tb.Links {
+		addPort(l.A)
+		addPort(l.B)
+	}
+	for _, td := range a.devices {
+		for _, tp := range td.Ports {
+			addPort(td.Id + ":" + tp.Id)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, td := range a.devices {
+		ids[td.Id] = true
+	}
+	for _, dev := range append(append([]*bindpb.Device{}, b.Duts...), b.Ates...) {
+		if dev.Id != "" && !ids[dev.Id] {
+			errs = append(errs, fmt.Errorf("binding device %q not found in testbed", dev.Id))
+		}
+		if _, ok := opb.Device_Vendor_value[dev.Vendor]; dev.Vendor != "" && !ok {
+			errs = append(errs, fmt.Errorf("binding device %q has unknown vendor %q", dev.Name, dev.Vendor))
+		}
+		for _, p := range dev.Ports {
+			a.byName[dev.Name+":"+p.Name] = p
+		}
+	}
+	for _, l := range b.Links {
+		for _, end := range []string{l.A, l.B} {
+			if _, ok := a.byName[end]; !ok {
+				errs = append(errs, fmt.Errorf("binding link end %q is not a port of the binding", end))
+			}
+			if _, ok := a.bPeer[end]; ok {
+				errs = append(errs, fmt.Errorf("binding port %q is in more than one link", end))
+			}
+		}
+		a.bPeer[l.A] = l.B
+		a.bPeer[l.B] = l.A
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return a, nil
+}
+
+// fail records why a step failed, if it is the deepest so far.
+func (a *assigner) fail(step int, format string, args ...interface{}) {
+	if step >= a.depth {
+		a.depth = step
+		a.failure = fmt.Sprintf(format, args...)
+	}
+}
+
+// assignDevice assigns the testbed devices from i onward, then the ports.
+func (a *assigner) assignDevice(i int) bool {
+	if i == len(a.devices) {
+		return a.assignPort(0)
+	}
+	td := a.devices[i]
+	candidates := a.b.Ates
+	kind := "ATE"
+	if td.dut {
+		candidates = a.b.Duts
+		kind = "DUT"
+	}
+	for _, bd := range candidates {
+		if !a.deviceFits(td, bd) {
+			continue
+		}
+		a.devOf[td.Id] = bd
+		a.usedDev[bd] = true
+		if a.assignDevice(i + 1) {
+			return true
+		}
+		delete(a.devOf, td.Id)
+		delete(a.usedDev, bd)
+	}
+	a.fail(i, "no binding %s fits testbed %s %q", kind, kind, td.Id)
+	return false
+}
+
+// deviceFits reports whether the binding device may be assigned the testbed
+// device.
+func (a *assigner) deviceFits(td *tbDevice, bd *bindpb.Device) bool {
+	if a.usedDev[bd] {
+		return false
+	}
+	if bd.Id != "" && bd.Id != td.Id {
+		return false
+	}
+	if bd.Id == "" && a.hasFixedDevice(td) {
+		return false
+	}
+	if name, ok := a.partial[td.Id]; ok && name != bd.Name {
+		return false
+	}
+	if !a.kindFits(td, bd) {
+		return false
+	}
+	// The ports not mapped by ID must have enough candidates.
+	fixed := make(map[string]bool)
+	free := 0
+	for _, p := range bd.Ports {
+		if p.Id == "" {
+			free++
+		} else {
+			fixed[p.Id] = true
+		}
+	}
+	need := 0
+	for _, tp := range td.Ports {
+		if !fixed[tp.Id] {
+			need++
+		}
+	}
+	return need <= free
+}
+
+// kindFits reports whether the vendor, hardware model and software version of
+// the binding device satisfy the testbed device.  An attribute that is not set
+// in the binding device is not checked.
+func (a *assigner) kindFits(td *tbDevice, bd *bindpb.Device) bool {
+	if td.Vendor != opb.Device_VENDOR_UNSPECIFIED && bd.Vendor != "" && bd.Vendor != td.Vendor.String() {
+		return false
+	}
+	if bd.HardwareModel != "" && !a.attrFits(td.GetHardwareModel(), td.GetHardwareModelRegex(), bd.HardwareModel) {
+		return false
+	}
+	if bd.SoftwareVersion != "" && !a.attrFits(td.GetSoftwareVersion(), td.GetSoftwareVersionRegex(), bd.SoftwareVersion) {
+		return false
+	}
+	return true
+}
+
+// attrFits reports whether a device attribute satisfies the value or the
+// regular expression of the testbed, which matches an attribute containing
+// any match, as in the testbed.
+func (a *assigner) attrFits(value, regex, attr string) bool {
+	switch {
+	case value != "":
+		return value == attr
+	case regex != "":
+		return a.regexps[regex].MatchString(attr)
+	default:
+		return true
+	}
+}
+
+// hasFixedDevice reports whether a binding device is mapped to the testbed
+// device by ID.
+func (a *assigner) hasFixedDevice(td *tbDevice) bool {
+	candidates := a.b.Ates
+	if td.dut {
+		candidates = a.b.Duts
+	}
+	for _, bd := range candidates {
+		if bd.Id == td.Id {
+			return true
+		}
+	}
+	return false
+}
+
+// assignPort assigns the testbed ports from i onward.
+func (a *assigner) assignPort(i int) bool {
+	if i == len(a.ports) {
+		return true
+	}
+	tp := a.ports[i]
+	bd := a.devOf[tp.dev.Id]
+	step := len(a.devices) + i
+	for _, bp := range bd.Ports {
+		if !a.portFits(tp, bd, bp) || !a.linkFits(tp, bd, bp) {
+			continue
+		}
+		a.portOf[tp.id()] = bp
+		a.usedPort[bp] = true
+		if a.assignPort(i + 1) {
+			return true
+		}
+		delete(a.portOf, tp.id())
+		delete(a.usedPort, bp)
+	}
+	a.fail(step, "no port of binding device %q fits testbed port %q", bd.Name, tp.id())
+	return false
+}
+
+// portFits reports whether the binding port may be assigned the testbed port,
+// regardless of the links.
+func (a *assigner) portFits(tp *tbPort, bd *bindpb.Device, bp *bindpb.Port) bool {
+	if a.usedPort[bp] {
+		return false
+	}
+	if bp.Id != "" && bp.Id != tp.port.Id {
+		return false
+	}
+	if bp.Id == "" {
+		for _, p := range bd.Ports {
+			if p.Id == tp.port.Id {
+				return false // The port is mapped by ID.
+			}
+		}
+	}
+	if name, ok := a.partial[tp.id()]; ok && name != bp.Name {
+		return false
+	}
+	if tp.port.Speed != opb.Port_SPEED_UNSPECIFIED && bp.SpeedGbps != 0 && int32(tp.port.Speed) != bp.SpeedGbps {
+		return false
+	}
+	return true
+}
+
+// linkFits reports whether assigning the binding port to the testbed port
+// keeps the testbed link of the port satisfiable by the binding links.
+func (a *assigner) linkFits(tp *tbPort, bd *bindpb.Device, bp *bindpb.Port) bool {
+	tpeer, ok := a.tbPeer[tp.id()]
+	if !ok {
+		return true
+	}
+	bpeer, ok := a.bPeer[bd.Name+":"+bp.Name]
+	if !ok {
+		// A port mapped by ID is trusted to be linked, as in a binding which
+		// maps the testbed exactly.
+		return bp.Id != ""
+	}
+	if assigned, ok := a.portOf[tpeer]; ok {
+		peerDev := a.devOf[strings.SplitN(tpeer, ":", 2)[0]]
+		return bpeer == peerDev.Name+":"+assigned.Name
+	}
+	// The peer is not assigned yet: the binding peer must fit it.
+	peerID := strings.SplitN(tpeer, ":", 2)
+	peerDev := a.devOf[peerID[0]]
+	peerPort := a.byName[bpeer]
+	if peerDev == nil || !strings.HasPrefix(bpeer, peerDev.Name+":") || peerPort == nil {
+		return false
+	}
+	for _, p := range a.ports {
+		if p.id() == tpeer {
+			return a.portFits(p, peerDev, peerPort)
+		}
+	}
+	return false
+}
+
+// resolved returns the binding of the assigned testbed devices and ports.
+func (a *assigner) resolved() *bindpb.Binding {
+	rb := &bindpb.Binding{
+		Options: a.b.Options,
+		Links:   a.b.Links,
+	}
+	for _, td := range a.devices {
+		bd := proto.Clone(a.devOf[td.Id]).(*bindpb.Device)
+		bd.Id = td.Id
+		bd.Ports = nil
+		for _, tp := range td.Ports {
+			bp := proto.Clone(a.portOf[td.Id+":"+tp.Id]).(*bindpb.Port)
+			bp.Id = tp.Id
+			bd.Ports = append(bd.Ports, bp)
+		}
+		if td.dut {
+			rb.Duts = append(rb.Duts, bd)
+		} else {
+			rb.Ates = append(rb.Ates, bd)
+		}
+	}
+	return rb
+}
@@ -0,0 +1,350 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// linkedTestbed returns a testbed of a DUT and an ATE with n links.
+func linkedTestbed(n int, speed opb.Port_Speed) *opb.Testbed {
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{Id: "dut"}},
+		Ates: []*opb.Device{{Id: "ate"}},
+	}
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("port%d", i)
+		tb.Duts[0].Ports = append(tb.Duts[0].Ports, &opb.Port{Id: id, Speed: speed})
+		tb.Ates[0].Ports = append(tb.Ates[0].Ports, &opb.Port{Id: id, Speed: speed})
+		tb.Links = append(tb.Links, &opb.Link{A: "dut:" + id, B: "ate:" + id})
+	}
+	return tb
+}
+
+// poolBinding returns a pool of a DUT and an ATE whose ports are linked
+// pairwise, with the given speeds.
+func poolBinding(speeds ...int32) *bindpb.Binding {
+	b := &bindpb.Binding{
+		Duts: []*bindpb.Device{{Name: "dut1"}},
+		Ates: []*bindpb.Device{{Name: "ate1"}},
+	}
+	for i, speed := range speeds {
+		dp := fmt.Sprintf("Ethernet%d", i+1)
+		ap := fmt.Sprintf("1/%d", i+1)
+		b.Duts[0].Ports = append(b.Duts[0].Ports, &bindpb.Port{Name: dp, SpeedGbps: speed})
+		b.Ates[0].Ports = append(b.Ates[0].Ports, &bindpb.Port{Name: ap, SpeedGbps: speed})
+		b.Links = append(b.Links, &bindpb.Link{A: "dut1:" + dp, B: "ate1:" + ap})
+	}
+	return b
+}
+
+// assignedPorts returns the port names of the resolved binding keyed by
+// "<device ID>:<port ID>", and the device names keyed by ID.
+func assignedPorts(b *bindpb.Binding) map[string]string {
+	m := make(map[string]string)
+	for _, dev := range append(append([]*bindpb.Device{}, b.Duts...), b.Ates...) {
+		m[dev.Id] = dev.Name
+		for _, p := range dev.Ports {
+			m[dev.Id+":"+p.Id] = p.Name
+		}
+	}
+	return m
+}
+
+func TestAssign(t *testing.T) {
+	twoDUTs := poolBinding(100, 100)
+	twoDUTs.Duts = append(twoDUTs.Duts, &bindpb.Device{
+		Name:  "dut2",
+		Ports: []*bindpb.Port{{Name: "et-0/0/0"}, {Name: "et-0/0/1"}},
+	})
+	twoDUTs.Ates[0].Ports = append(twoDUTs.Ates[0].Ports, &bindpb.Port{Name: "2/1"}, &bindpb.Port{Name: "2/2"})
+	twoDUTs.Links = append(twoDUTs.Links,
+		&bindpb.Link{A: "dut2:et-0/0/0", B: "ate1:2/1"},
+		&bindpb.Link{A: "dut2:et-0/0/1", B: "ate1:2/2"})
+
+	fixed := poolBinding(10, 10, 10)
+	fixed.Duts[0].Id = "dut"
+	fixed.Duts[0].Ports[2].Id = "port1"
+
+	cases := []struct {
+		desc    string
+		tb      *opb.Testbed
+		b       *bindpb.Binding
+		partial map[string]string
+		want    map[string]string
+	}{{
+		desc: "first links",
+		tb:   linkedTestbed(2, opb.Port_SPEED_UNSPECIFIED),
+		b:    poolBinding(10, 10, 10, 10),
+		want: map[string]string{
+			"dut": "dut1", "dut:port1": "Ethernet1", "dut:port2": "Ethernet2",
+			"ate": "ate1", "ate:port1": "1/1", "ate:port2": "1/2",
+		},
+	}, {
+		desc: "speed",
+		tb:   linkedTestbed(2, opb.Port_S_100GB),
+		b:    poolBinding(10, 100, 10, 100),
+		want: map[string]string{
+			"dut": "dut1", "dut:port1": "Ethernet2", "dut:port2": "Ethernet4",
+			"ate": "ate1", "ate:port1": "1/2", "ate:port2": "1/4",
+		},
+	}, {
+		desc:    "port pin follows the link",
+		tb:      linkedTestbed(2, opb.Port_SPEED_UNSPECIFIED),
+		b:       poolBinding(10, 10, 10, 10),
+		partial: map[string]string{"ate:port1": "1/3"},
+		want: map[string]string{
+			"dut": "dut1", "dut:port1": "Ethernet3", "dut:port2": "Ethernet1",
+			"ate": "ate1", "ate:port1": "1/3", "ate:port2": "1/1",
+		},
+	}, {
+		desc:    "device pin",
+		tb:      linkedTestbed(2, opb.Port_SPEED_UNSPECIFIED),
+		b:       twoDUTs,
+		partial: map[string]string{"dut": "dut2"},
+		want: map[string]string{
+			"dut": "dut2", "dut:port1": "et-0/0/0", "dut:port2": "et-0/0/1",
+			"ate": "ate1", "ate:port1": "2/1", "ate:port2": "2/2",
+		},
+	}, {
+		desc: "cardinality",
+		tb:   linkedTestbed(3, opb.Port_SPEED_UNSPECIFIED),
+		b:    twoDUTs,
+		want: nil,
+	}, {
+		desc: "fixed device and port",
+		tb:   linkedTestbed(2, opb.Port_SPEED_UNSPECIFIED),
+		b:    fixed,
+		want: map[string]string{
+			"dut": "dut1", "dut:port1": "Ethernet3", "dut:port2": "Ethernet1",
+			"ate": "ate1", "ate:port1": "1/3", "ate:port2": "1/1",
+		},
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := assign(c.tb, c.b, c.partial)
+			if c.want == nil {
+				if err == nil {
+					t.Fatalf("assign got %v, want error", assignedPorts(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("assign got error: %v", err)
+			}
+			if diff := cmp.Diff(c.want, assignedPorts(got)); diff != "" {
+				t.Errorf("assign diff (-want +got):\n%s", diff)
+			}
+			if _, err := reservation(c.tb, resolver{got}); err != nil {
+				t.Errorf("reservation of the assigned binding got error: %v", err)
+			}
+		})
+	}
+}
+
+// labPool returns a pool like atedut_pool.binding, with the DUTs of the given
+// vendors and models, each with n ports of the given speed linked to its own
+// ports of one ATE of ateSpeed.
+func labPool(n int, speed, ateSpeed int32, duts ...*bindpb.Device) *bindpb.Binding {
+	b := &bindpb.Binding{Ates: []*bindpb.Device{{Name: "ate1"}}}
+	for i, dut := range duts {
+		for j := 1; j <= n; j++ {
+			dp := fmt.Sprintf("Ethernet%d/1", j)
+			ap := fmt.Sprintf("%d/%d", i+1, j)
+			dut.Ports = append(dut.Ports, &bindpb.Port{Name: dp, SpeedGbps: speed})
+			b.Ates[0].Ports = append(b.Ates[0].Ports, &bindpb.Port{Name: ap, SpeedGbps: ateSpeed})
+			b.Links = append(b.Links, &bindpb.Link{A: dut.Name + ":" + dp, B: "ate1:" + ap})
+		}
+		b.Duts = append(b.Duts, dut)
+	}
+	return b
+}
+
+func TestAssign_DeviceKind(t *testing.T) {
+	tb := linkedTestbed(12, opb.Port_S_100GB)
+	tb.Duts[0].Vendor = opb.Device_ARISTA
+	tb.Duts[0].HardwareModelValue = &opb.Device_HardwareModelRegex{HardwareModelRegex: "7280"}
+	tb.Duts[0].SoftwareVersionValue = &opb.Device_SoftwareVersion{SoftwareVersion: "4.29.1F"}
+
+	// Only dut5 fits: dut1 is of another vendor, dut2 of another model, dut3
+	// of another software version, and the ATE ports linked to dut4 are too
+	// slow, which is only found when assigning the ports.
+	b := labPool(16, 100, 100,
+		&bindpb.Device{Name: "dut1", Vendor: "JUNIPER", HardwareModel: "PTX10008", SoftwareVersion: "4.29.1F"},
+		&bindpb.Device{Name: "dut2", Vendor: "ARISTA", HardwareModel: "DCS-7050SX3", SoftwareVersion: "4.29.1F"},
+		&bindpb.Device{Name: "dut3", Vendor: "ARISTA", HardwareModel: "DCS-7280CR3K", SoftwareVersion: "4.28.0F"},
+		&bindpb.Device{Name: "dut4", Vendor: "ARISTA", HardwareModel: "DCS-7280CR3K", SoftwareVersion: "4.29.1F"},
+		&bindpb.Device{Name: "dut5", Vendor: "ARISTA", HardwareModel: "DCS-7280CR3K", SoftwareVersion: "4.29.1F"},
+	)
+	for _, p := range b.Ates[0].Ports {
+		if strings.HasPrefix(p.Name, "4/") {
+			p.SpeedGbps = 10
+		}
+	}
+
+	got, err := assign(tb, b, nil)
+	if err != nil {
+		t.Fatalf("assign got error: %v", err)
+	}
+	want := map[string]string{"dut": "dut5", "ate": "ate1"}
+	for i := 1; i <= 12; i++ {
+		want[fmt.Sprintf("dut:port%d", i)] = fmt.Sprintf("Ethernet%d/1", i)
+		want[fmt.Sprintf("ate:port%d", i)] = fmt.Sprintf("5/%d", i)
+	}
+	if diff := cmp.Diff(want, assignedPorts(got)); diff != "" {
+		t.Errorf("assign diff (-want +got):\n%s", diff)
+	}
+
+	resv, err := reservation(tb, resolver{got})
+	if err != nil {
+		t.Fatalf("reservation of the assigned binding got error: %v", err)
+	}
+	dut := resv.DUTs["dut"]
+	if got, want := dut.Vendor(), opb.Device_ARISTA; got != want {
+		t.Errorf("DUT vendor got %v, want %v", got, want)
+	}
+	if got, want := dut.HardwareModel(), "DCS-7280CR3K"; got != want {
+		t.Errorf("DUT hardware model got %q, want %q", got, want)
+	}
+	if got, want := dut.SoftwareVersion(), "4.29.1F"; got != want {
+		t.Errorf("DUT software version got %q, want %q", got, want)
+	}
+}
+
+func TestAssign_Exact(t *testing.T) {
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut", Ports: []*opb.Port{{Id: "port1"}}}}}
+	b := &bindpb.Binding{
+		Duts: []*bindpb.Device{{
+			Id:    "dut",
+			Name:  "dut.name",
+			Ports: []*bindpb.Port{{Id: "port1", Name: "Ethernet1"}},
+		}},
+	}
+	got, err := assign(tb, b, map[string]string{"dut": "dut.name", "dut:port1": "Ethernet1"})
+	if err != nil {
+		t.Fatalf("assign got error: %v", err)
+	}
+	if diff := cmp.Diff(b, got, protocmp.Transform()); diff != "" {
+		t.Errorf("assign diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestAssign_Errors(t *testing.T) {
+	exact := &bindpb.Binding{
+		Duts: []*bindpb.Device{{
+			Id:    "dut",
+			Name:  "dut.name",
+			Ports: []*bindpb.Port{{Id: "port1", Name: "Ethernet1"}},
+		}},
+		Ates: []*bindpb.Device{{
+			Id:    "ate",
+			Name:  "ate.name",
+			Ports: []*bindpb.Port{{Id: "port1", Name: "1/1"}},
+		}},
+	}
+	badLink := poolBinding(10)
+	badLink.Links = append(badLink.Links, &bindpb.Link{A: "dut1:Ethernet9", B: "ate1:1/1"})
+	strayID := poolBinding(10)
+	strayID.Duts[0].Id = "dut9"
+
+	cases := []struct {
+		desc    string
+		b       *bindpb.Binding
+		partial map[string]string
+		want    string
+	}{{
+		desc:    "unknown pin",
+		b:       exact,
+		partial: map[string]string{"dut:port9": "Ethernet9"},
+		want:    `"dut:port9" is not a device or port of the testbed`,
+	}, {
+		desc:    "exact device pin",
+		b:       exact,
+		partial: map[string]string{"dut": "other.name"},
+		want:    "reserve pin dut=other.name, but the binding has dut.name",
+	}, {
+		desc:    "exact port pin",
+		b:       exact,
+		partial: map[string]string{"ate:port1": "2/1"},
+		want:    "reserve pin ate:port1=2/1, but the binding has 1/1",
+	}, {
+		desc: "link to unknown port",
+		b:    badLink,
+		want: `"dut1:Ethernet9" is not a port of the binding`,
+	}, {
+		desc: "port in two links",
+		b:    badLink,
+		want: `"ate1:1/1" is in more than one link`,
+	}, {
+		desc: "device ID not in testbed",
+		b:    strayID,
+		want: `binding device "dut9" not found in testbed`,
+	}, {
+		desc:    "unsatisfiable pin",
+		b:       poolBinding(10, 10),
+		partial: map[string]string{"dut": "dut2"},
+		want:    `no binding DUT fits testbed DUT "dut"`,
+	}, {
+		desc: "unknown vendor",
+		b:    &bindpb.Binding{Duts: []*bindpb.Device{{Name: "dut1", Vendor: "ACME"}}},
+		want: `binding device "dut1" has unknown vendor "ACME"`,
+	}, {
+		desc: "unsatisfiable speed",
+		b:    poolBinding(100),
+		want: `no port of binding device "dut1" fits testbed port "dut:port1"`,
+	}}
+	tb := linkedTestbed(1, opb.Port_S_10GB)
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := assign(tb, c.b, c.partial)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("assign got error %v, want it to contain %q", err, c.want)
+			}
+		})
+	}
+}
+
+func TestReserve_Pool(t *testing.T) {
+	ctx := context.Background()
+	sb := &staticBind{r: resolver{poolBinding(10, 10, 10, 10)}}
+	resv, err := sb.Reserve(ctx, linkedTestbed(2, opb.Port_SPEED_UNSPECIFIED), 0, 0, map[string]string{"dut:port2": "Ethernet1"})
+	if err != nil {
+		t.Fatalf("Reserve got error: %v", err)
+	}
+	t.Cleanup(func() { sb.Release(ctx) })
+	got := map[string]string{
+		"dut":       resv.DUTs["dut"].Name(),
+		"dut:port1": resv.DUTs["dut"].Ports()["port1"].Name,
+		"dut:port2": resv.DUTs["dut"].Ports()["port2"].Name,
+		"ate:port2": resv.ATEs["ate"].Ports()["port2"].Name,
+	}
+	want := map[string]string{
+		"dut":       "dut1",
+		"dut:port1": "Ethernet2",
+		"dut:port2": "Ethernet1",
+		"ate:port2": "1/1",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Reserve diff (-want +got):\n%s", diff)
+	}
+}
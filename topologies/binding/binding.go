@@ -18,7 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/open-traffic-generator/snappi/gosnappi"
@@ -27,6 +30,7 @@ import (
 	"github.com/openconfig/ondatra/binding/ixweb"
 	"google.golang.org/grpc"
 
+	"github.com/openconfig/featureprofiles/internal/deviations"
 	"github.com/openconfig/featureprofiles/internal/rundata"
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
@@ -43,6 +47,14 @@ type staticBind struct {
 	r          resolver
 	resv       *binding.Reservation
 	pushConfig bool
+
+	// preflightMode is the -preflight flag.
+	preflightMode string
+
+	// lockDir is the -lock-dir flag, and locked are the devices locked in it
+	// for the reservation.
+	lockDir string
+	locked  []string
 }
 
 type staticDUT struct {
@@ -63,26 +75,114 @@ var _ = binding.Binding(&staticBind{})
 
 const resvID = "STATIC"
 
+var (
+	// reservedMu guards reserved, the static binding with a reservation.
+	reservedMu sync.Mutex
+	reserved   *staticBind
+)
+
+// ErrNoStaticDUT is returned by ResetDUT and DialGNSI for a DUT which is not
+// reserved through a static binding.
+var ErrNoStaticDUT = errors.New("no DUT with this name in a static binding reservation")
+
+// UsesOTG reports whether the named ATE is driven through the OTG API, i.e.
+// whether the static binding has OTG options for it.  The ATEs of other
+// bindings, e.g. KNE, only have the OTG API.
+func UsesOTG(name string) bool {
+	reservedMu.Lock()
+	b := reserved
+	reservedMu.Unlock()
+	if b == nil {
+		return true
+	}
+	for _, ate := range b.resv.ATEs {
+		if sate, ok := ate.(*staticATE); ok && sate.Name() == name {
+			return sate.dev.Otg != nil
+		}
+	}
+	return true
+}
+
+// ResetDUT resets the named DUT of the static binding reservation with
+// the reset config of the binding, i.e. Configs cli, cli_file,
+// gnmi_set_file and gribi_flush, regardless of -push-config.
+func ResetDUT(ctx context.Context, name string) error {
+	dut, err := reservedDUT(name)
+	if err != nil {
+		return err
+	}
+	return dut.reset(ctx)
+}
+
+// reservedDUT looks up the named DUT of the static binding reservation.
+func reservedDUT(name string) (*staticDUT, error) {
+	reservedMu.Lock()
+	b := reserved
+	reservedMu.Unlock()
+	if b == nil {
+		return nil, ErrNoStaticDUT
+	}
+	for _, dut := range b.resv.DUTs {
+		if sdut, ok := dut.(*staticDUT); ok && sdut.Name() == name {
+			return sdut, nil
+		}
+	}
+	return nil, ErrNoStaticDUT
+}
+
 func (b *staticBind) Reserve(ctx context.Context, tb *opb.Testbed, runTime, waitTime time.Duration, partial map[string]string) (*binding.Reservation, error) {
 	if b.resv != nil {
 		return nil, fmt.Errorf("only one reservation is allowed")
 	}
-	resv, err := reservation(tb, b.r)
+	rb, err := assign(tb, b.r.Binding, partial)
+	if err != nil {
+		return nil, err
+	}
+	resv, err := reservation(tb, resolver{rb})
 	if err != nil {
 		return nil, err
 	}
 	resv.ID = resvID
+	if b.lockDir != "" {
+		locked, err := lockDevices(ctx, b.lockDir, resv, runTime, waitTime)
+		if err != nil {
+			return nil, err
+		}
+		b.locked = locked
+	}
 	b.resv = resv
 
-	if err := b.afterReserve(ctx); err != nil {
-		return nil, err
-	}
-	if err := b.reserveIxSessions(ctx); err != nil {
+	if err := b.setup(ctx); err != nil {
+		b.resv = nil
+		if uerr := b.unlock(); uerr != nil {
+			return nil, allerrors{err, uerr}
+		}
 		return nil, err
 	}
+	reservedMu.Lock()
+	reserved = b
+	reservedMu.Unlock()
 	return resv, nil
 }
 
+// setup prepares the devices of the reservation for the test run.
+func (b *staticBind) setup(ctx context.Context) error {
+	if err := b.setDeviations(ctx); err != nil {
+		return err
+	}
+	if err := b.afterReserve(ctx); err != nil {
+		return err
+	}
+	return b.reserveIxSessions(ctx)
+}
+
+// unlock frees the locks of the reserved devices.
+func (b *staticBind) unlock() error {
+	err := unlockDevices(b.lockDir, b.locked)
+	b.locked = nil
+	return err
+}
+
 func (b *staticBind) Release(ctx context.Context) error {
 	m := rundata.Timing(ctx)
 	for k, v := range m {
@@ -91,10 +191,24 @@ func (b *staticBind) Release(ctx context.Context) error {
 	if b.resv == nil {
 		return errors.New("no reservation")
 	}
+	// The reservation ends and its devices are unlocked even if releasing the
+	// IxNetwork sessions fails.
+	var errs allerrors
 	if err := b.releaseIxSessions(ctx); err != nil {
-		return err
+		errs = append(errs, err)
 	}
 	b.resv = nil
+	reservedMu.Lock()
+	if reserved == b {
+		reserved = nil
+	}
+	reservedMu.Unlock()
+	if err := b.unlock(); err != nil {
+		errs = append(errs, err)
+	}
+	if errs != nil {
+		return errs
+	}
 	return nil
 }
 
@@ -108,42 +222,131 @@ func (b *staticBind) FetchReservation(ctx context.Context, id string) (*binding.
 	return b.resv, nil
 }
 
+// addSuiteProperty records a run property in the test report; it is a
+// variable for the tests.
+var addSuiteProperty = func(k, v string) {
+	ondatra.Report().AddSuiteProperty(k, v)
+}
+
 func (b *staticBind) afterReserve(ctx context.Context) error {
 	m := rundata.Properties(ctx, b.resv)
 	for k, v := range m {
-		ondatra.Report().AddSuiteProperty(k, v)
+		addSuiteProperty(k, v)
 	}
 
+	if err := b.preflight(ctx); err != nil {
+		return err
+	}
 	if !b.pushConfig {
 		return nil
 	}
 	return b.reset(ctx)
 }
 
+// setDeviations sets the per-device deviations from the deviation profiles and the
+// binding.  The DUTs are visited in the order of their IDs, so that the first
+// error is the same on every run.  The deviation profile applied to a DUT is
+// recorded in the suite property "<id>.deviation_profile".
+func (b *staticBind) setDeviations(ctx context.Context) error {
+	ids := make([]string, 0, len(b.resv.DUTs))
+	for id := range b.resv.DUTs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		sdut, ok := b.resv.DUTs[id].(*staticDUT)
+		if !ok {
+			continue
+		}
+		file, err := sdut.applyDeviationProfile(ctx)
+		if err != nil {
+			return fmt.Errorf("could not apply deviation profile to %s: %w", sdut.Name(), err)
+		}
+		if file != "" {
+			addSuiteProperty(id+".deviation_profile", filepath.Base(file))
+		}
+		if err := deviations.SetDevice(sdut.Name(), sdut.dev.GetDeviations()); err != nil {
+			return fmt.Errorf("could not set deviations: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyDeviationProfile applies the deviation profile that matches the vendor,
+// model, and OS version of the DUT, as reported over gNMI.  The vendor, model, and
+// OS version in the testbed are used for what the DUT does not report.  It returns
+// the profile file, or an empty string if no profile applies.
+func (d *staticDUT) applyDeviationProfile(ctx context.Context) (string, error) {
+	if !deviations.HasProfiles() {
+		return "", nil
+	}
+	dialer, err := d.r.gnmi(d.Name())
+	if err != nil {
+		return "", err
+	}
+	conn, err := dialer.dialGRPC(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	di, err := rundata.NewDUTInfo(ctx, gpb.NewGNMIClient(conn))
+	if err != nil {
+		return "", err
+	}
+	vendor, model, osVersion := di.ShortVendor(), di.ShortModel(), di.OSVer
+	if vendor == "" && d.Vendor() != opb.Device_VENDOR_UNSPECIFIED {
+		vendor = d.Vendor().String()
+	}
+	if model == "" {
+		model = d.HardwareModel()
+	}
+	if osVersion == "" {
+		osVersion = d.SoftwareVersion()
+	}
+	return deviations.ApplyProfile(d.Name(), vendor, model, osVersion)
+}
+
+// reset resets the DUTs in parallel.
 func (b *staticBind) reset(ctx context.Context) error {
+	var sduts []*staticDUT
 	for _, dut := range b.resv.DUTs {
 		if sdut, ok := dut.(*staticDUT); ok {
+			sduts = append(sduts, sdut)
+		}
+	}
+	sort.Slice(sduts, func(i, j int) bool { return sduts[i].Name() < sduts[j].Name() })
+
+	errs := make([]error, len(sduts))
+	var wg sync.WaitGroup
+	for i, sdut := range sduts {
+		wg.Add(1)
+		go func(i int, sdut *staticDUT) {
+			defer wg.Done()
 			if err := sdut.reset(ctx); err != nil {
-				return fmt.Errorf("could not reset device %s: %w", sdut.Name(), err)
+				errs[i] = fmt.Errorf("could not reset device %s: %w", sdut.Name(), err)
 			}
+		}(i, sdut)
+	}
+	wg.Wait()
+
+	var all allerrors
+	for _, err := range errs {
+		if err != nil {
+			all = append(all, err)
 		}
 	}
+	if all != nil {
+		return all
+	}
 	return nil
 }
 
+// reset runs the reset steps of the DUT, each of which is a no-op if the
+// reset action is not requested.
 func (d *staticDUT) reset(ctx context.Context) error {
-	// Each of the individual reset functions should be no-op if the reset action is not
-	// requested.
-	if err := resetCLI(ctx, d.dev, d.r); err != nil {
-		return err
-	}
-	if err := resetGNMI(ctx, d.dev, d.r); err != nil {
-		return err
-	}
-	if err := resetGRIBI(ctx, d.dev, d.r); err != nil {
-		return err
-	}
-	return nil
+	return resetDevice(ctx, d.dev, d.r)
 }
 
 func (d *staticDUT) DialGNMI(ctx context.Context, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
@@ -170,6 +373,20 @@ func (d *staticDUT) DialGNOI(ctx context.Context, opts ...grpc.DialOption) (bind
 	return gnoiConn{conn: conn}, nil
 }
 
+// DialGNSI dials the gNSI services of the DUT.  It is not part of the
+// Ondatra binding.DUT interface; see the package function DialGNSI.
+func (d *staticDUT) DialGNSI(ctx context.Context, opts ...grpc.DialOption) (GNSIClients, error) {
+	dialer, err := d.r.gnsi(d.Name())
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dialer.dialGRPC(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gnsiConn{conn: conn}, nil
+}
+
 func (d *staticDUT) DialGRIBI(ctx context.Context, opts ...grpc.DialOption) (grpb.GRIBIClient, error) {
 	dialer, err := d.r.gribi(d.Name())
 	if err != nil {
@@ -199,7 +416,7 @@ func (d *staticDUT) DialCLI(ctx context.Context) (binding.StreamClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	sc, err := dialer.dialSSH()
+	sc, err := dialer.dialSSH(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -343,11 +560,25 @@ func dims(td *opb.Device, bd *bindpb.Device) (*binding.Dims, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The device attributes of the binding, checked against the testbed by
+	// the assignment of a pool, are those of the actual device.
+	vendor := td.Vendor
+	if v, ok := opb.Device_Vendor_value[bd.Vendor]; ok {
+		vendor = opb.Device_Vendor(v)
+	}
+	model := td.GetHardwareModel()
+	if bd.HardwareModel != "" {
+		model = bd.HardwareModel
+	}
+	version := td.GetSoftwareVersion()
+	if bd.SoftwareVersion != "" {
+		version = bd.SoftwareVersion
+	}
 	return &binding.Dims{
 		Name:            bd.Name,
-		Vendor:          td.Vendor,
-		HardwareModel:   td.GetHardwareModel(),
-		SoftwareVersion: td.GetSoftwareVersion(),
+		Vendor:          vendor,
+		HardwareModel:   model,
+		SoftwareVersion: version,
 		Ports:           portmap,
 	}, nil
 }
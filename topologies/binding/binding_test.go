@@ -16,14 +16,26 @@ package binding
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/featureprofiles/internal/deviations"
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	"github.com/openconfig/ondatra/binding"
 	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
+
+	authzpb "github.com/openconfig/gnsi/authz"
+	pathzpb "github.com/openconfig/gnsi/pathz"
 )
 
 func TestReserveFetchRelease(t *testing.T) {
@@ -228,3 +240,197 @@ func TestReservation_Error(t *testing.T) {
 		}
 	}
 }
+
+func TestSetDeviations(t *testing.T) {
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{Id: "dut1"}, {Id: "dut2"}},
+	}
+	b := &bindpb.Binding{
+		Duts: []*bindpb.Device{{
+			Id:   "dut1",
+			Name: "dut1.name",
+			Deviations: map[string]string{
+				"deviation_interface_enabled": "true",
+			},
+		}, {
+			Id:   "dut2",
+			Name: "dut2.name",
+		}},
+	}
+	resv, err := reservation(tb, resolver{b})
+	if err != nil {
+		t.Fatalf("Error building reservation: %v", err)
+	}
+	sb := &staticBind{r: resolver{b}, resv: resv}
+	if err := sb.setDeviations(context.Background()); err != nil {
+		t.Fatalf("setDeviations got error: %v", err)
+	}
+	if !deviations.For(resv.DUTs["dut1"]).InterfaceEnabled() {
+		t.Errorf("dut1 InterfaceEnabled() got false, want true")
+	}
+	if got, want := deviations.For(resv.DUTs["dut2"]).InterfaceEnabled(), *deviations.InterfaceEnabled; got != want {
+		t.Errorf("dut2 InterfaceEnabled() got %v, want %v", got, want)
+	}
+
+	b.Duts[1].Deviations = map[string]string{"deviation_interface_enabled": "maybe"}
+	if err := sb.setDeviations(context.Background()); err == nil {
+		t.Errorf("setDeviations with a bad value got no error")
+	}
+}
+
+func TestSetDeviations_Profile(t *testing.T) {
+	props := make(map[string]string)
+	oldAdd := addSuiteProperty
+	addSuiteProperty = func(k, v string) { props[k] = v }
+	t.Cleanup(func() { addSuiteProperty = oldAdd })
+
+	dir := t.TempDir()
+	for _, name := range []string{"arista_a.textproto", "arista_b.textproto"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(`vendor: "ARISTA"`), 0644); err != nil {
+			t.Fatalf("Could not write profile: %v", err)
+		}
+	}
+	if err := flag.Set("deviation_profile_dir", dir); err != nil {
+		t.Fatalf("Could not set --deviation_profile_dir: %v", err)
+	}
+	t.Cleanup(func() { flag.Set("deviation_profile_dir", "") })
+
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut1", Vendor: opb.Device_ARISTA}}}
+	b := &bindpb.Binding{
+		Options: &bindpb.Options{Insecure: true},
+		Duts: []*bindpb.Device{{
+			Id:   "dut1",
+			Name: "dut1.name",
+			Gnmi: &bindpb.Options{Target: newFakeGNMI(t)},
+		}},
+	}
+	resv, err := reservation(tb, resolver{b})
+	if err != nil {
+		t.Fatalf("Error building reservation: %v", err)
+	}
+	sb := &staticBind{r: resolver{b}, resv: resv}
+	err = sb.setDeviations(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("setDeviations with ambiguous profiles got error %v, want ambiguous", err)
+	}
+	if got := props["dut1.deviation_profile"]; got != "" {
+		t.Errorf("deviation_profile property got %q, want none", got)
+	}
+}
+
+func TestUsesOTG(t *testing.T) {
+	if !UsesOTG("ate1.name") {
+		t.Errorf("UsesOTG without a static binding got false, want true")
+	}
+	tb := &opb.Testbed{Ates: []*opb.Device{{Id: "ate1"}, {Id: "ate2"}}}
+	r := resolver{&bindpb.Binding{
+		Ates: []*bindpb.Device{{
+			Id:   "ate1",
+			Name: "ate1.name",
+			Otg:  &bindpb.Options{Target: "ate1.name:40051"},
+		}, {
+			Id:        "ate2",
+			Name:      "ate2.name",
+			Ixnetwork: &bindpb.Options{Target: "ixnetwork.name"},
+		}},
+	}}
+	resv, err := reservation(tb, r)
+	if err != nil {
+		t.Fatalf("Error building reservation: %v", err)
+	}
+	reservedMu.Lock()
+	reserved = &staticBind{r: r, resv: resv}
+	reservedMu.Unlock()
+	t.Cleanup(func() {
+		reservedMu.Lock()
+		reserved = nil
+		reservedMu.Unlock()
+	})
+	if !UsesOTG("ate1.name") {
+		t.Errorf("UsesOTG of an ATE with OTG options got false, want true")
+	}
+	if UsesOTG("ate2.name") {
+		t.Errorf("UsesOTG of an IxNetwork ATE got true, want false")
+	}
+}
+
+func TestResetDUT(t *testing.T) {
+	ctx := context.Background()
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut1"}}}
+	b := &staticBind{r: resolver{&bindpb.Binding{
+		Duts: []*bindpb.Device{{
+			Id:   "dut1",
+			Name: "dut1.name",
+			Config: &bindpb.Configs{
+				GnmiSetFile: []string{filepath.Join(t.TempDir(), "missing.textproto")},
+			},
+		}},
+	}}}
+
+	if err := ResetDUT(ctx, "dut1.name"); !errors.Is(err, ErrNoStaticDUT) {
+		t.Errorf("ResetDUT before reservation got error %v, want %v", err, ErrNoStaticDUT)
+	}
+	if _, err := b.Reserve(ctx, tb, 0, 0, nil); err != nil {
+		t.Fatalf("Could not reserve testbed: %v", err)
+	}
+	if err := ResetDUT(ctx, "dut2.name"); !errors.Is(err, ErrNoStaticDUT) {
+		t.Errorf("ResetDUT of an unknown DUT got error %v, want %v", err, ErrNoStaticDUT)
+	}
+	if err := ResetDUT(ctx, "dut1.name"); err == nil || errors.Is(err, ErrNoStaticDUT) {
+		t.Errorf("ResetDUT got error %v, want the error reading the gnmi_set_file", err)
+	}
+	if err := b.Release(ctx); err != nil {
+		t.Fatalf("Could not release reservation: %v", err)
+	}
+	if err := ResetDUT(ctx, "dut1.name"); !errors.Is(err, ErrNoStaticDUT) {
+		t.Errorf("ResetDUT after release got error %v, want %v", err, ErrNoStaticDUT)
+	}
+}
+
+func TestDialGNSI(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	authzpb.RegisterAuthzServer(srv, &authzpb.UnimplementedAuthzServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut1"}}}
+	b := &staticBind{r: resolver{&bindpb.Binding{
+		Duts: []*bindpb.Device{{
+			Id:   "dut1",
+			Name: "dut1.name",
+			Gnsi: &bindpb.Options{
+				Target:   lis.Addr().String(),
+				Insecure: true,
+			},
+		}},
+	}}}
+
+	if _, err := DialGNSI(ctx, "dut1.name"); !errors.Is(err, ErrNoStaticDUT) {
+		t.Errorf("DialGNSI before reservation got error %v, want %v", err, ErrNoStaticDUT)
+	}
+	if _, err := b.Reserve(ctx, tb, 0, 0, nil); err != nil {
+		t.Fatalf("Could not reserve testbed: %v", err)
+	}
+	t.Cleanup(func() { b.Release(ctx) })
+	if _, err := DialGNSI(ctx, "dut2.name"); !errors.Is(err, ErrNoStaticDUT) {
+		t.Errorf("DialGNSI of an unknown DUT got error %v, want %v", err, ErrNoStaticDUT)
+	}
+	gnsi, err := DialGNSI(ctx, "dut1.name")
+	if err != nil {
+		t.Fatalf("DialGNSI got error: %v", err)
+	}
+	// The server only has the default Authz implementation, so a call that
+	// reaches it is unimplemented, while the other services are unknown.
+	_, err = gnsi.Authz().Get(ctx, &authzpb.GetRequest{})
+	if got, want := status.Code(err), codes.Unimplemented; got != want {
+		t.Errorf("Authz().Get got code %v, want %v", got, want)
+	}
+	if _, err := gnsi.Pathz().Get(ctx, &pathzpb.GetRequest{}); err == nil {
+		t.Errorf("Pathz().Get got nil error, want an unknown service error")
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"fmt"
+
+	"github.com/openconfig/featureprofiles/internal/deviations"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Redacted replaces the secrets of the options reported by Check.
+const Redacted = "REDACTED"
+
+// Target is the resolved dial target and options of a protocol of a device.
+type Target struct {
+	ID       string // Device ID in the testbed.
+	Name     string // Device name in the binding.
+	Protocol string
+	Options  *bindpb.Options // With the secrets replaced by Redacted.
+}
+
+// Check resolves the binding against the testbed the way Reserve does, but
+// offline, without dialing any device and without pins.  A binding pool is
+// assigned to the testbed first.  It also checks that the config files
+// exist and parse, that the deviations are known, and that the TLS and SSH
+// key files load.  It returns the dial targets of every device and protocol,
+// or all the problems found.
+func Check(tb *opb.Testbed, b *bindpb.Binding) ([]*Target, error) {
+	if err := validate(b); err != nil {
+		return nil, err
+	}
+	b, err := assign(tb, b, nil)
+	if err != nil {
+		return nil, err
+	}
+	r := resolver{b}
+	if _, err := reservation(tb, r); err != nil {
+		return nil, err
+	}
+
+	var errs allerrors
+	var targets []*Target
+	add := func(dev *bindpb.Device, protocol string, d dialer, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("device %q %s: %w", dev.Id, protocol, err))
+			return
+		}
+		if err := checkDialer(protocol, d); err != nil {
+			errs = append(errs, fmt.Errorf("device %q %s: %w", dev.Id, protocol, err))
+		}
+		targets = append(targets, &Target{
+			ID:       dev.Id,
+			Name:     dev.Name,
+			Protocol: protocol,
+			Options:  redact(d.Options),
+		})
+	}
+
+	for _, dut := range b.Duts {
+		if err := checkConfigs(dut.GetConfig()); err != nil {
+			errs = append(errs, fmt.Errorf("device %q config: %w", dut.Id, err))
+		}
+		if err := deviations.SetDevice(dut.Name, dut.GetDeviations()); err != nil {
+			errs = append(errs, fmt.Errorf("device %q deviations: %w", dut.Id, err))
+		}
+		d, err := r.ssh(dut.Name)
+		add(dut, "ssh", d, err)
+		d, err = r.gnmi(dut.Name)
+		add(dut, "gnmi", d, err)
+		d, err = r.gnoi(dut.Name)
+		add(dut, "gnoi", d, err)
+		d, err = r.gnsi(dut.Name)
+		add(dut, "gnsi", d, err)
+		d, err = r.gribi(dut.Name)
+		add(dut, "gribi", d, err)
+		d, err = r.p4rt(dut.Name)
+		add(dut, "p4rt", d, err)
+	}
+	for _, ate := range b.Ates {
+		d, err := r.ateGNMI(ate.Name)
+		add(ate, "gnmi", d, err)
+		if ate.Otg != nil {
+			d, err = r.ateOtg(ate.Name)
+			add(ate, "otg", d, err)
+		} else {
+			d, err = r.ixnetwork(ate.Name)
+			add(ate, "ixnetwork", d, err)
+		}
+	}
+
+	if errs != nil {
+		return targets, errs
+	}
+	return targets, nil
+}
+
+// checkConfigs checks that the config files exist and parse.
+func checkConfigs(c *bindpb.Configs) error {
+	var errs allerrors
+	for _, file := range c.GetCliFile() {
+		if _, err := readCLI(file); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, file := range c.GetGnmiSetFile() {
+		if _, err := readGNMI(file); err != nil {
+			errs = append(errs, fmt.Errorf("gnmi_set_file %s: %w", file, err))
+		}
+	}
+	for i, step := range c.GetSteps() {
+		if err := checkStep(step); err != nil {
+			errs = append(errs, fmt.Errorf("reset step %q: %w", stepName(i, step), err))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// checkStep checks that the files of the reset step exist and parse.
+func checkStep(s *bindpb.ResetStep) error {
+	switch c := s.GetConfig().(type) {
+	case *bindpb.ResetStep_CliFile:
+		if _, err := readCLI(c.CliFile); err != nil {
+			return err
+		}
+	case *bindpb.ResetStep_GnmiSetFile:
+		if _, err := readGNMI(c.GnmiSetFile); err != nil {
+			return fmt.Errorf("gnmi_set_file %s: %w", c.GnmiSetFile, err)
+		}
+	}
+	if v := s.GetVerify(); v != nil {
+		if _, _, err := readVerification(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDialer checks that the key and certificate files of the dialer load.
+func checkDialer(protocol string, d dialer) error {
+	switch protocol {
+	case "ssh":
+		if d.SshPrivateKeyFile != "" {
+			_, err := d.sshSigner()
+			return err
+		}
+		return nil
+	default:
+		_, err := d.tlsConfig()
+		return err
+	}
+}
+
+// redact returns a copy of the options with the secrets of secretOptions
+// replaced.
+func redact(o *bindpb.Options) *bindpb.Options {
+	o = proto.Clone(o).(*bindpb.Options)
+	for _, secret := range secretOptions(o) {
+		if *secret != "" {
+			*secret = Redacted
+		}
+	}
+	return o
+}
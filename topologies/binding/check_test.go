@@ -0,0 +1,183 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	cliFile := filepath.Join(dir, "dut.cli")
+	if err := os.WriteFile(cliFile, []byte("hostname dut"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	gnmiFile := filepath.Join(dir, "dut.textproto")
+	if err := os.WriteFile(gnmiFile, []byte(`replace { path {} val { json_ietf_val: "{}" } }`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{Id: "dut", Ports: []*opb.Port{{Id: "port1"}}}},
+		Ates: []*opb.Device{{Id: "ate", Ports: []*opb.Port{{Id: "port1"}}}},
+	}
+	b := &bindpb.Binding{
+		Options: &bindpb.Options{Username: "admin", Password: "secret", SshPrivateKeyPassphrase: "phrase"},
+		Duts: []*bindpb.Device{{
+			Id:    "dut",
+			Name:  "dut.name",
+			Ports: []*bindpb.Port{{Id: "port1", Name: "Ethernet1"}},
+			Gnmi:  &bindpb.Options{Insecure: true},
+			Config: &bindpb.Configs{
+				CliFile:     []string{cliFile},
+				GnmiSetFile: []string{gnmiFile},
+			},
+		}},
+		Ates: []*bindpb.Device{{
+			Id:    "ate",
+			Name:  "ate.name",
+			Ports: []*bindpb.Port{{Id: "port1", Name: "1/1"}},
+			Otg:   &bindpb.Options{Target: "otg.name:443", SkipVerify: true},
+		}},
+	}
+
+	got, err := Check(tb, b)
+	if err != nil {
+		t.Fatalf("Check got error: %v", err)
+	}
+	creds := func(o *bindpb.Options) *bindpb.Options {
+		o.Username = Redacted
+		o.Password = Redacted
+		o.SshPrivateKeyPassphrase = Redacted
+		return o
+	}
+	want := []*Target{
+		{"dut", "dut.name", "ssh", creds(&bindpb.Options{Target: "dut.name"})},
+		{"dut", "dut.name", "gnmi", creds(&bindpb.Options{Target: "dut.name:" + strconv.Itoa(*gnmiPort), Insecure: true})},
+		{"dut", "dut.name", "gnoi", creds(&bindpb.Options{Target: "dut.name:" + strconv.Itoa(*gnoiPort)})},
+		{"dut", "dut.name", "gnsi", creds(&bindpb.Options{Target: "dut.name:" + strconv.Itoa(*gnsiPort)})},
+		{"dut", "dut.name", "gribi", creds(&bindpb.Options{Target: "dut.name:" + strconv.Itoa(*gribiPort)})},
+		{"dut", "dut.name", "p4rt", creds(&bindpb.Options{Target: "dut.name:" + strconv.Itoa(*p4rtPort)})},
+		{"ate", "ate.name", "gnmi", creds(&bindpb.Options{Target: "ate.name:" + strconv.Itoa(*ateGnmiPort)})},
+		{"ate", "ate.name", "otg", creds(&bindpb.Options{Target: "otg.name:443", SkipVerify: true})},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Check diff (-want +got):\n%s", diff)
+	}
+	if b.Options.Username != "admin" || b.Options.Password != "secret" || b.Options.SshPrivateKeyPassphrase != "phrase" {
+		t.Errorf("Check changed the binding credentials to %v", b.Options)
+	}
+}
+
+func TestCheck_Errors(t *testing.T) {
+	dir := t.TempDir()
+	badGNMI := filepath.Join(dir, "bad.textproto")
+	if err := os.WriteFile(badGNMI, []byte("not a SetRequest"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tb := &opb.Testbed{
+		Duts: []*opb.Device{{Id: "dut", Ports: []*opb.Port{{Id: "port1"}}}},
+		Ates: []*opb.Device{{Id: "ate"}},
+	}
+
+	cases := []struct {
+		desc    string
+		binding *bindpb.Binding
+		want    []string
+	}{{
+		desc: "otg and ixnetwork",
+		binding: &bindpb.Binding{
+			Ates: []*bindpb.Device{{
+				Id:        "ate",
+				Name:      "ate.name",
+				Otg:       &bindpb.Options{},
+				Ixnetwork: &bindpb.Options{},
+			}},
+		},
+		want: []string{"mutually exclusive"},
+	}, {
+		desc: "reservation",
+		binding: &bindpb.Binding{
+			Duts: []*bindpb.Device{{Id: "dut2", Name: "dut2.name"}},
+		},
+		want: []string{`missing binding for DUT "dut"`, `binding DUT "dut2" not found`, `missing binding for ATE "ate"`},
+	}, {
+		desc: "port",
+		binding: &bindpb.Binding{
+			Duts: []*bindpb.Device{{Id: "dut", Name: "dut.name", Ports: []*bindpb.Port{{Id: "port2", Name: "Ethernet2"}}}},
+			Ates: []*bindpb.Device{{Id: "ate", Name: "ate.name"}},
+		},
+		want: []string{`binding port "port2" not found`, `testbed port "port1" is missing`},
+	}, {
+		desc: "files",
+		binding: &bindpb.Binding{
+			Duts: []*bindpb.Device{{
+				Id:    "dut",
+				Name:  "dut.name",
+				Ports: []*bindpb.Port{{Id: "port1", Name: "Ethernet1"}},
+				Config: &bindpb.Configs{
+					CliFile:     []string{filepath.Join(dir, "missing.cli")},
+					GnmiSetFile: []string{badGNMI},
+				},
+				Deviations: map[string]string{"deviation_no_such_thing": "true"},
+				Ssh:        &bindpb.Options{SshPrivateKeyFile: filepath.Join(dir, "missing.key")},
+				Gnmi:       &bindpb.Options{TrustBundleFile: filepath.Join(dir, "missing.pem")},
+			}},
+			Ates: []*bindpb.Device{{Id: "ate", Name: "ate.name"}},
+		},
+		want: []string{"missing.cli", "gnmi_set_file " + badGNMI, `device "dut" deviations`, `device "dut" ssh`, `device "dut" gnmi`},
+	}, {
+		desc: "reset steps",
+		binding: &bindpb.Binding{
+			Duts: []*bindpb.Device{{
+				Id:    "dut",
+				Name:  "dut.name",
+				Ports: []*bindpb.Port{{Id: "port1", Name: "Ethernet1"}},
+				Config: &bindpb.Configs{
+					Steps: []*bindpb.ResetStep{{
+						Config: &bindpb.ResetStep_GnmiSetFile{GnmiSetFile: badGNMI},
+					}, {
+						Name:   "verified",
+						Verify: &bindpb.Verification{GnmiGetFile: filepath.Join(dir, "missing.textproto")},
+					}},
+				},
+			}},
+			Ates: []*bindpb.Device{{Id: "ate", Name: "ate.name"}},
+		},
+		want: []string{`reset step "step 1": gnmi_set_file ` + badGNMI, `reset step "verified"`},
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := Check(tb, c.binding)
+			if err == nil {
+				t.Fatalf("Check got nil error, want %q", c.want)
+			}
+			for _, want := range c.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Check got error %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
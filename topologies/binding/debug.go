@@ -0,0 +1,308 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	fpb "github.com/openconfig/gnoi/file"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+	"github.com/openconfig/ygot/ygot"
+)
+
+// CollectDebug collects the debug data configured in the binding from every
+// DUT of the static binding reservation, into a subdirectory of dir named
+// after the DUT ID.  Each source is collected even if others fail.  It does
+// nothing without a static binding reservation.
+func CollectDebug(ctx context.Context, dir string) error {
+	reservedMu.Lock()
+	b := reserved
+	reservedMu.Unlock()
+	if b == nil {
+		return nil
+	}
+	var ids []string
+	for id := range b.resv.DUTs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var errs allerrors
+	for _, id := range ids {
+		sdut, ok := b.resv.DUTs[id].(*staticDUT)
+		if !ok {
+			continue
+		}
+		if err := sdut.collectDebug(ctx, filepath.Join(dir, id)); err != nil {
+			errs = append(errs, fmt.Errorf("debug data of %s: %w", id, err))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+func (d *staticDUT) collectDebug(ctx context.Context, dir string) error {
+	var errs allerrors
+	for _, collect := range []func(context.Context, *bindpb.Device, resolver, string) error{
+		debugCLI,
+		debugFiles,
+		debugGNMI,
+		debugGRIBI,
+	} {
+		if err := collect(ctx, d.dev, d.r, dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// debugFilename returns the name of the file of a debug source, keeping only
+// the characters which are safe in a filename.  If other characters are
+// replaced, a hash of the source is appended so that the sources which differ
+// only in those characters, e.g. "show ip route" and "show ip_route", are
+// written to different files.
+func debugFilename(kind, source, ext string) string {
+	source = strings.Trim(source, "/ ")
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, source)
+	if name != source {
+		h := fnv.New32a()
+		h.Write([]byte(source))
+		name = fmt.Sprintf("%s_%08x", name, h.Sum32())
+	}
+	return kind + "_" + name + ext
+}
+
+// writeDebug writes a debug file in the directory, which is created if needed.
+func writeDebug(dir, name string, content []byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return err
+	}
+	glog.Infof("Debug data written: %s", path)
+	return nil
+}
+
+// writeDebugProto writes the messages as a text proto debug file.
+func writeDebugProto(dir, name string, msgs ...proto.Message) error {
+	var content []byte
+	for _, m := range msgs {
+		text, err := prototext.MarshalOptions{Multiline: true}.Marshal(m)
+		if err != nil {
+			return err
+		}
+		content = append(content, text...)
+	}
+	return writeDebug(dir, name, content)
+}
+
+// debugCLI collects the output of the CLI show commands.
+func debugCLI(ctx context.Context, bdut *bindpb.Device, r resolver, dir string) error {
+	cmds := bdut.GetDebug().GetCli()
+	if len(cmds) == 0 {
+		return nil
+	}
+	dialer, err := r.ssh(bdut.GetName())
+	if err != nil {
+		return err
+	}
+	sc, err := dialer.dialSSH(ctx)
+	if err != nil {
+		return err
+	}
+	cli, err := newCLI(sc)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	var errs allerrors
+	for _, cmd := range cmds {
+		out, err := cli.SendCommand(ctx, cmd)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cli %q: %w", cmd, err))
+			continue
+		}
+		if err := writeDebug(dir, debugFilename("cli", cmd, ".txt"), []byte(out)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// debugFiles collects the files on the device with gNOI File.Get.
+func debugFiles(ctx context.Context, bdut *bindpb.Device, r resolver, dir string) error {
+	files := bdut.GetDebug().GetFile()
+	if len(files) == 0 {
+		return nil
+	}
+	dialer, err := r.gnoi(bdut.GetName())
+	if err != nil {
+		return err
+	}
+	conn, err := dialer.dialGRPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	file := fpb.NewFileClient(conn)
+	var errs allerrors
+	for _, remote := range files {
+		content, err := getFile(ctx, file, remote)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("file %q: %w", remote, err))
+			continue
+		}
+		if err := writeDebug(dir, debugFilename("file", remote, ""), content); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// getFile reads the contents of a remote file with gNOI File.Get.
+func getFile(ctx context.Context, file fpb.FileClient, remote string) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := file.Get(ctx, &fpb.GetRequest{RemoteFile: remote})
+	if err != nil {
+		return nil, err
+	}
+	var content []byte
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return content, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, resp.GetContents()...)
+	}
+}
+
+// debugGNMI collects the state of the gNMI paths.
+func debugGNMI(ctx context.Context, bdut *bindpb.Device, r resolver, dir string) error {
+	paths := bdut.GetDebug().GetGnmiPath()
+	if len(paths) == 0 {
+		return nil
+	}
+	dialer, err := r.gnmi(bdut.GetName())
+	if err != nil {
+		return err
+	}
+	conn, err := dialer.dialGRPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	gnmi := gpb.NewGNMIClient(conn)
+	var errs allerrors
+	for _, p := range paths {
+		path, err := ygot.StringToStructuredPath(p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("gnmi path %q: %w", p, err))
+			continue
+		}
+		resp, err := gnmi.Get(ctx, &gpb.GetRequest{
+			Path:     []*gpb.Path{path},
+			Type:     gpb.GetRequest_STATE,
+			Encoding: gpb.Encoding_JSON_IETF,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("gnmi path %q: %w", p, err))
+			continue
+		}
+		if err := writeDebugProto(dir, debugFilename("gnmi", p, ".textproto"), resp); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// debugGRIBI collects the gRIBI Get output of all the network instances.
+func debugGRIBI(ctx context.Context, bdut *bindpb.Device, r resolver, dir string) error {
+	if !bdut.GetDebug().GetGribiGet() {
+		return nil
+	}
+	dialer, err := r.gribi(bdut.GetName())
+	if err != nil {
+		return err
+	}
+	conn, err := dialer.dialGRPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := grpb.NewGRIBIClient(conn).Get(ctx, &grpb.GetRequest{
+		NetworkInstance: &grpb.GetRequest_All{All: &grpb.Empty{}},
+		Aft:             grpb.AFTType_ALL,
+	})
+	if err != nil {
+		return fmt.Errorf("gribi get: %w", err)
+	}
+	var resps []proto.Message
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("gribi get: %w", err)
+		}
+		resps = append(resps, resp)
+	}
+	return writeDebugProto(dir, "gribi_get.textproto", resps...)
+}
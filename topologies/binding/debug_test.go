@@ -0,0 +1,166 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	fpb "github.com/openconfig/gnoi/file"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+)
+
+// fakeGNMIGet answers Get, failing for the paths under /missing.
+type fakeGNMIGet struct {
+	gpb.UnimplementedGNMIServer
+}
+
+func (fakeGNMIGet) Get(ctx context.Context, req *gpb.GetRequest) (*gpb.GetResponse, error) {
+	if req.GetPath()[0].GetElem()[0].GetName() == "missing" {
+		return nil, status.Error(codes.NotFound, "no such path")
+	}
+	return &gpb.GetResponse{Notification: []*gpb.Notification{{Timestamp: 42}}}, nil
+}
+
+// fakeFile answers Get with the contents "log of <file>".
+type fakeFile struct {
+	fpb.UnimplementedFileServer
+}
+
+func (fakeFile) Get(req *fpb.GetRequest, stream fpb.File_GetServer) error {
+	for _, chunk := range []string{"log ", "of ", req.GetRemoteFile()} {
+		if err := stream.Send(&fpb.GetResponse{Response: &fpb.GetResponse_Contents{Contents: []byte(chunk)}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeGRIBIGet answers Get with one entry.
+type fakeGRIBIGet struct {
+	grpb.UnimplementedGRIBIServer
+}
+
+func (fakeGRIBIGet) Get(req *grpb.GetRequest, stream grpb.GRIBI_GetServer) error {
+	return stream.Send(&grpb.GetResponse{Entry: []*grpb.AFTEntry{{NetworkInstance: "DEFAULT"}}})
+}
+
+func TestCollectDebug(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	gpb.RegisterGNMIServer(srv, fakeGNMIGet{})
+	fpb.RegisterFileServer(srv, fakeFile{})
+	grpb.RegisterGRIBIServer(srv, fakeGRIBIGet{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := CollectDebug(ctx, dir); err != nil {
+		t.Errorf("CollectDebug before reservation got error: %v", err)
+	}
+
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut1"}, {Id: "dut2"}}}
+	b := &staticBind{r: resolver{&bindpb.Binding{
+		Options: &bindpb.Options{Target: lis.Addr().String(), Insecure: true},
+		Duts: []*bindpb.Device{{
+			Id:   "dut1",
+			Name: "dut1.name",
+			Debug: &bindpb.Debug{
+				File:     []string{"/var/log/messages"},
+				GnmiPath: []string{"/interfaces/interface[name=Ethernet1]", "/missing"},
+				GribiGet: true,
+			},
+		}, {
+			Id:   "dut2",
+			Name: "dut2.name",
+		}},
+	}}}
+	if _, err := b.Reserve(ctx, tb, 0, 0, nil); err != nil {
+		t.Fatalf("Could not reserve testbed: %v", err)
+	}
+	t.Cleanup(func() { b.Release(ctx) })
+
+	err = CollectDebug(ctx, dir)
+	if err == nil || !strings.Contains(err.Error(), `gnmi path "/missing"`) {
+		t.Errorf("CollectDebug got error %v, want the error of /missing", err)
+	}
+
+	var got []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			got = append(got, rel)
+		}
+		return nil
+	})
+	sort.Strings(got)
+	want := []string{
+		"dut1/file_var_log_messages_d05406f0",
+		"dut1/gnmi_interfaces_interface_name_Ethernet1__939410a9.textproto",
+		"dut1/gribi_get.textproto",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CollectDebug files diff (-want +got):\n%s", diff)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "dut1/file_var_log_messages_d05406f0"))
+	if err != nil {
+		t.Fatalf("Cannot read the collected file: %v", err)
+	}
+	if got, want := string(content), "log of /var/log/messages"; got != want {
+		t.Errorf("Collected file got %q, want %q", got, want)
+	}
+	content, err = os.ReadFile(filepath.Join(dir, "dut1/gribi_get.textproto"))
+	if err != nil {
+		t.Fatalf("Cannot read the gRIBI Get output: %v", err)
+	}
+	if !strings.Contains(string(content), `"DEFAULT"`) {
+		t.Errorf("gRIBI Get output got %q, want the DEFAULT entry", content)
+	}
+}
+
+func TestDebugFilename(t *testing.T) {
+	tests := []struct {
+		kind, source, ext string
+		want              string
+	}{
+		{"cli", "version", ".txt", "cli_version.txt"},
+		{"cli", "show ip route", ".txt", "cli_show_ip_route_2ce89a34.txt"},
+		{"cli", "show ip_route", ".txt", "cli_show_ip_route_9b176f17.txt"},
+		{"file", "/var/log/messages", "", "file_var_log_messages_d05406f0"},
+	}
+	for _, tt := range tests {
+		if got := debugFilename(tt.kind, tt.source, tt.ext); got != tt.want {
+			t.Errorf("debugFilename(%q, %q, %q) got %q, want %q", tt.kind, tt.source, tt.ext, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	authzpb "github.com/openconfig/gnsi/authz"
+	certzpb "github.com/openconfig/gnsi/certz"
+	credzpb "github.com/openconfig/gnsi/credentialz"
+	pathzpb "github.com/openconfig/gnsi/pathz"
+)
+
+// GNSIClients are the clients of the gNSI services of a DUT.
+type GNSIClients interface {
+	Authz() authzpb.AuthzClient
+	Certz() certzpb.CertzClient
+	Credentialz() credzpb.CredentialzClient
+	Pathz() pathzpb.PathzClient
+}
+
+// gnsiConn implements GNSIClients over a gRPC connection.
+type gnsiConn struct {
+	conn *grpc.ClientConn
+}
+
+func (g gnsiConn) Authz() authzpb.AuthzClient { return authzpb.NewAuthzClient(g.conn) }
+func (g gnsiConn) Certz() certzpb.CertzClient { return certzpb.NewCertzClient(g.conn) }
+func (g gnsiConn) Credentialz() credzpb.CredentialzClient {
+	return credzpb.NewCredentialzClient(g.conn)
+}
+func (g gnsiConn) Pathz() pathzpb.PathzClient { return pathzpb.NewPathzClient(g.conn) }
+
+var _ = GNSIClients(gnsiConn{})
+
+// DialGNSI dials the gNSI services of the named DUT of the static binding
+// reservation, using the gnsi options of the binding.  Ondatra has no gNSI
+// API, so security tests get the clients here:
+//
+//	gnsi, err := binding.DialGNSI(ctx, dut.Name())
+//	if err != nil {
+//		t.Fatalf("Cannot dial gNSI: %v", err)
+//	}
+//	resp, err := gnsi.Authz().Get(ctx, &authzpb.GetRequest{})
+func DialGNSI(ctx context.Context, name string, opts ...grpc.DialOption) (GNSIClients, error) {
+	dut, err := reservedDUT(name)
+	if err != nil {
+		return nil, err
+	}
+	return dut.DialGNSI(ctx, opts...)
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"time"
+
+	"github.com/openconfig/ondatra/binding"
+	"google.golang.org/grpc"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+// GNMIInterceptor makes the gRPC client interceptor for the gNMI
+// connections of a DUT.
+type GNMIInterceptor func(dut binding.DUT) grpc.UnaryClientInterceptor
+
+// interceptBind wraps a binding so that the gNMI connections of its
+// DUTs go through an interceptor.
+type interceptBind struct {
+	binding.Binding
+	gnmi GNMIInterceptor
+}
+
+type interceptDUT struct {
+	binding.DUT
+	gnmi grpc.UnaryClientInterceptor
+}
+
+// WithGNMIInterceptor wraps any binding, static or not, so that every
+// gNMI connection dialed to its DUTs goes through the interceptor made
+// by the given function.
+func WithGNMIInterceptor(b binding.Binding, gnmi GNMIInterceptor) binding.Binding {
+	return &interceptBind{Binding: b, gnmi: gnmi}
+}
+
+func (b *interceptBind) Reserve(ctx context.Context, tb *opb.Testbed, runTime, waitTime time.Duration, partial map[string]string) (*binding.Reservation, error) {
+	resv, err := b.Binding.Reserve(ctx, tb, runTime, waitTime, partial)
+	return b.intercept(resv), err
+}
+
+func (b *interceptBind) FetchReservation(ctx context.Context, id string) (*binding.Reservation, error) {
+	resv, err := b.Binding.FetchReservation(ctx, id)
+	return b.intercept(resv), err
+}
+
+// intercept returns a copy of the reservation with wrapped DUTs.  The
+// reservation itself is left alone since the wrapped binding may still
+// use its DUTs, e.g. to reset them.
+func (b *interceptBind) intercept(resv *binding.Reservation) *binding.Reservation {
+	if resv == nil {
+		return nil
+	}
+	wrapped := &binding.Reservation{
+		ID:   resv.ID,
+		DUTs: make(map[string]binding.DUT, len(resv.DUTs)),
+		ATEs: resv.ATEs,
+	}
+	for id, dut := range resv.DUTs {
+		wrapped.DUTs[id] = &interceptDUT{DUT: dut, gnmi: b.gnmi(dut)}
+	}
+	return wrapped
+}
+
+func (d *interceptDUT) DialGNMI(ctx context.Context, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
+	opts = append(opts, grpc.WithChainUnaryInterceptor(d.gnmi))
+	return d.DUT.DialGNMI(ctx, opts...)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openconfig/ondatra/binding"
+	"google.golang.org/grpc"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+type fakeBind struct {
+	binding.Binding
+	resv *binding.Reservation
+}
+
+func (b *fakeBind) Reserve(context.Context, *opb.Testbed, time.Duration, time.Duration, map[string]string) (*binding.Reservation, error) {
+	return b.resv, nil
+}
+
+type fakeDUT struct {
+	*binding.AbstractDUT
+	opts []grpc.DialOption
+}
+
+func (d *fakeDUT) DialGNMI(ctx context.Context, opts ...grpc.DialOption) (gpb.GNMIClient, error) {
+	d.opts = opts
+	return nil, nil
+}
+
+func TestWithGNMIInterceptor(t *testing.T) {
+	dut := &fakeDUT{AbstractDUT: &binding.AbstractDUT{Dims: &binding.Dims{Name: "dut1"}}}
+	b := &fakeBind{resv: &binding.Reservation{DUTs: map[string]binding.DUT{"dut": dut}}}
+
+	var intercepted []string
+	ib := WithGNMIInterceptor(b, func(d binding.DUT) grpc.UnaryClientInterceptor {
+		intercepted = append(intercepted, d.Name())
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	})
+
+	resv, err := ib.Reserve(context.Background(), &opb.Testbed{}, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("Reserve got error: %v", err)
+	}
+	if len(intercepted) != 1 || intercepted[0] != "dut1" {
+		t.Errorf("Interceptors made for %v, want [dut1]", intercepted)
+	}
+	if b.resv.DUTs["dut"] != dut {
+		t.Errorf("Reserve modified the reservation of the wrapped binding")
+	}
+	if _, err := resv.DUTs["dut"].DialGNMI(context.Background(), grpc.WithBlock()); err != nil {
+		t.Fatalf("DialGNMI got error: %v", err)
+	}
+	if got, want := len(dut.opts), 2; got != want {
+		t.Errorf("DialGNMI got %d dial options, want %d with the interceptor", got, want)
+	}
+}
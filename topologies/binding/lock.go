@@ -0,0 +1,313 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/openconfig/ondatra/binding"
+)
+
+// lockSuffix is the suffix of the lock files in the lock directory.
+const lockSuffix = ".lock"
+
+var (
+	// defaultLockWait is the wait for the locks when Reserve is given no
+	// waitTime.
+	defaultLockWait = 5 * time.Minute
+
+	// lockPoll is the interval between attempts to take a lock.
+	lockPoll = time.Second
+)
+
+// Lock is an advisory lock of a device of a static binding, held by a test
+// run for its reservation.  It is a file named after the device in the lock
+// directory, which should be shared by everyone using the same lab.
+type Lock struct {
+	Device  string    `json:"device"`
+	Owner   string    `json:"owner"`
+	Host    string    `json:"host"`
+	PID     int       `json:"pid"`
+	Created time.Time `json:"created"`
+	Expires time.Time `json:"expires"` // Zero if the lock does not expire.
+}
+
+// Stale reports whether the lock may be broken: it expired, or its process
+// on this host is gone.
+func (l *Lock) Stale(now time.Time) bool {
+	if !l.Expires.IsZero() && now.After(l.Expires) {
+		return true
+	}
+	if host, err := os.Hostname(); err == nil && host == l.Host {
+		return !processAlive(l.PID)
+	}
+	return false
+}
+
+func (l *Lock) String() string {
+	expires := "never"
+	if !l.Expires.IsZero() {
+		expires = l.Expires.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s locked by %s on %s (pid %d) since %s, expires %s",
+		l.Device, l.Owner, l.Host, l.PID, l.Created.Format(time.RFC3339), expires)
+}
+
+// processAlive reports whether a process with the PID runs on this host.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, os.ErrPermission)
+}
+
+func lockPath(dir, device string) string {
+	return filepath.Join(dir, device+lockSuffix)
+}
+
+// newLock returns the lock of the device by this process for the runTime.
+func newLock(device string, now time.Time, runTime time.Duration) *Lock {
+	l := &Lock{
+		Device:  device,
+		Owner:   os.Getenv("USER"),
+		PID:     os.Getpid(),
+		Created: now,
+	}
+	if u, err := user.Current(); err == nil {
+		l.Owner = u.Username
+	}
+	l.Host, _ = os.Hostname()
+	if runTime > 0 {
+		l.Expires = now.Add(runTime)
+	}
+	return l
+}
+
+// ReadLock reads the lock of the device in the lock directory.
+func ReadLock(dir, device string) (*Lock, error) {
+	l, _, err := readLock(dir, device)
+	return l, err
+}
+
+// readLock reads the lock of the device and its content as written.
+func readLock(dir, device string) (*Lock, []byte, error) {
+	data, err := os.ReadFile(lockPath(dir, device))
+	if err != nil {
+		return nil, nil, err
+	}
+	l := &Lock{}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, nil, fmt.Errorf("cannot parse the lock of %s: %w", device, err)
+	}
+	return l, data, nil
+}
+
+// ListLocks returns the locks in the lock directory, sorted by device.
+func ListLocks(dir string) ([]*Lock, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var locks []*Lock
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, lockSuffix) {
+			continue
+		}
+		l, err := ReadLock(dir, strings.TrimSuffix(name, lockSuffix))
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, l)
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].Device < locks[j].Device })
+	return locks, nil
+}
+
+// BreakLock removes the lock of the device, whoever holds it.
+func BreakLock(dir, device string) error {
+	return os.Remove(lockPath(dir, device))
+}
+
+// tryLock takes the lock of the device if it is free or stale.  It returns
+// the current holder if the lock is taken.
+func tryLock(dir string, l *Lock) (*Lock, error) {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	// Write the lock aside, then link it in place, so that the lock is never
+	// seen partially written and linking fails if it exists.
+	tmp, err := os.CreateTemp(dir, ".tmp-"+l.Device+"-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	for {
+		err := os.Link(tmp.Name(), lockPath(dir, l.Device))
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		holder, held, err := readLock(dir, l.Device)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // Released meanwhile.
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !holder.Stale(time.Now()) {
+			return holder, nil
+		}
+		if err := breakStale(dir, l.Device, held); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// breakStale removes the lock of the device if it is still the stale lock
+// with the given content.  Another run may have broken the stale lock and
+// taken the device since the content was read, so the lock is renamed aside
+// first and only removed if its content is the stale one; otherwise it is put
+// back in place.  If yet another run locked the device while the live lock
+// was aside, two runs hold the device: the live lock is kept aside and an
+// error is returned.
+func breakStale(dir, device string, stale []byte) error {
+	aside := fmt.Sprintf("%s.broken-%d-%d", lockPath(dir, device), os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(lockPath(dir, device), aside); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil // Released or broken meanwhile.
+		}
+		return err
+	}
+	afterAside()
+	data, err := os.ReadFile(aside)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, stale) {
+		return os.Remove(aside)
+	}
+	// Not the stale lock: put it back.
+	if err := os.Link(aside, lockPath(dir, device)); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s was locked by another run while its live lock was set aside in %s; both runs may use it", device, aside)
+		}
+		return fmt.Errorf("cannot put back the live lock set aside in %s: %w", aside, err)
+	}
+	return os.Remove(aside)
+}
+
+// afterAside is called by breakStale once the lock is set aside, for tests.
+var afterAside = func() {}
+
+// unlock removes the lock of the device if this process holds it.
+func unlock(dir, device string) error {
+	l, err := ReadLock(dir, device)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	host, _ := os.Hostname()
+	if l.PID != os.Getpid() || l.Host != host {
+		return fmt.Errorf("lock of %s is no longer ours: %v", device, l)
+	}
+	return BreakLock(dir, device)
+}
+
+// lockDevices takes the locks of the reserved devices in the lock directory,
+// waiting up to waitTime for them, or defaultLockWait if zero.  It takes all
+// the locks or none.
+func lockDevices(ctx context.Context, dir string, resv *binding.Reservation, runTime, waitTime time.Duration) ([]string, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, fmt.Errorf("cannot create the lock directory: %w", err)
+	}
+	var devices []string
+	for _, dut := range resv.DUTs {
+		devices = append(devices, dut.Name())
+	}
+	for _, ate := range resv.ATEs {
+		devices = append(devices, ate.Name())
+	}
+	// Lock in a fixed order, so that runs waiting for each other's devices
+	// do not deadlock.
+	sort.Strings(devices)
+
+	if waitTime == 0 {
+		waitTime = defaultLockWait
+	}
+	ctx, cancel := context.WithTimeout(ctx, waitTime)
+	defer cancel()
+
+	var locked []string
+	for _, device := range devices {
+		for {
+			holder, err := tryLock(dir, newLock(device, time.Now(), runTime))
+			if err != nil {
+				unlockDevices(dir, locked)
+				return nil, fmt.Errorf("cannot lock %s: %w", device, err)
+			}
+			if holder == nil {
+				locked = append(locked, device)
+				break
+			}
+			select {
+			case <-ctx.Done():
+				unlockDevices(dir, locked)
+				return nil, fmt.Errorf("timed out after %v waiting for the lock: %v", waitTime, holder)
+			case <-time.After(lockPoll):
+			}
+		}
+	}
+	return locked, nil
+}
+
+// unlockDevices removes the locks of the devices held by this process.
+func unlockDevices(dir string, devices []string) error {
+	var errs allerrors
+	for _, device := range devices {
+		if err := unlock(dir, device); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	"github.com/openconfig/ondatra/binding"
+	opb "github.com/openconfig/ondatra/proto"
+)
+
+// writeLock writes the lock in the lock directory as another holder would.
+func writeLock(t *testing.T, dir string, l *Lock) {
+	t.Helper()
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatalf("cannot marshal lock: %v", err)
+	}
+	if err := os.WriteFile(lockPath(dir, l.Device), data, 0666); err != nil {
+		t.Fatalf("cannot write lock: %v", err)
+	}
+}
+
+func lockedDevices(t *testing.T, dir string) []string {
+	t.Helper()
+	locks, err := ListLocks(dir)
+	if err != nil {
+		t.Fatalf("ListLocks got error: %v", err)
+	}
+	var devices []string
+	for _, l := range locks {
+		devices = append(devices, l.Device)
+	}
+	return devices
+}
+
+func testReservation() *binding.Reservation {
+	return &binding.Reservation{
+		DUTs: map[string]binding.DUT{
+			"dut": &staticDUT{AbstractDUT: &binding.AbstractDUT{Dims: &binding.Dims{Name: "dut1"}}},
+		},
+		ATEs: map[string]binding.ATE{
+			"ate": &staticATE{AbstractATE: &binding.AbstractATE{Dims: &binding.Dims{Name: "ate1"}}},
+		},
+	}
+}
+
+func TestStale(t *testing.T) {
+	now := time.Now()
+	host, err := os.Hostname()
+	if err != nil {
+		t.Fatalf("cannot get hostname: %v", err)
+	}
+	cases := []struct {
+		desc string
+		lock *Lock
+		want bool
+	}{{
+		desc: "held",
+		lock: &Lock{Host: host, PID: os.Getpid(), Expires: now.Add(time.Hour)},
+		want: false,
+	}, {
+		desc: "no expiry",
+		lock: &Lock{Host: "otherhost", PID: 1},
+		want: false,
+	}, {
+		desc: "expired",
+		lock: &Lock{Host: "otherhost", PID: 1, Expires: now.Add(-time.Second)},
+		want: true,
+	}, {
+		desc: "process gone",
+		lock: &Lock{Host: host, PID: 1 << 30},
+		want: true,
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := c.lock.Stale(now); got != c.want {
+				t.Errorf("Stale got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestLockDevices(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	locked, err := lockDevices(ctx, dir, testReservation(), time.Hour, time.Second)
+	if err != nil {
+		t.Fatalf("lockDevices got error: %v", err)
+	}
+	want := []string{"ate1", "dut1"}
+	if diff := cmp.Diff(want, locked); diff != "" {
+		t.Errorf("lockDevices diff (-want +got):\n%s", diff)
+	}
+	l, err := ReadLock(dir, "dut1")
+	if err != nil {
+		t.Fatalf("ReadLock got error: %v", err)
+	}
+	if l.PID != os.Getpid() || l.Expires.Sub(l.Created) != time.Hour {
+		t.Errorf("ReadLock got %v, want held by pid %d for 1h", l, os.Getpid())
+	}
+	if err := unlockDevices(dir, locked); err != nil {
+		t.Fatalf("unlockDevices got error: %v", err)
+	}
+	if got := lockedDevices(t, dir); len(got) != 0 {
+		t.Errorf("unlockDevices left locks of %v", got)
+	}
+}
+
+func TestLockDevices_Stale(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	host, _ := os.Hostname()
+	writeLock(t, dir, &Lock{Device: "ate1", Host: "otherhost", PID: 1, Expires: time.Now().Add(-time.Minute)})
+	writeLock(t, dir, &Lock{Device: "dut1", Host: host, PID: 1 << 30})
+	locked, err := lockDevices(ctx, dir, testReservation(), 0, time.Second)
+	if err != nil {
+		t.Fatalf("lockDevices got error: %v", err)
+	}
+	for _, device := range locked {
+		if l, err := ReadLock(dir, device); err != nil || l.PID != os.Getpid() {
+			t.Errorf("ReadLock(%q) got %v, %v, want held by pid %d", device, l, err, os.Getpid())
+		}
+	}
+}
+
+func TestLockDevices_Wait(t *testing.T) {
+	defer func(poll time.Duration) { lockPoll = poll }(lockPoll)
+	lockPoll = 10 * time.Millisecond
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	held := &Lock{Device: "dut1", Owner: "alice", Host: "otherhost", PID: 1}
+	writeLock(t, dir, held)
+
+	_, err := lockDevices(ctx, dir, testReservation(), 0, 50*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "locked by alice") {
+		t.Fatalf("lockDevices got error %v, want locked by alice", err)
+	}
+	if diff := cmp.Diff([]string{"dut1"}, lockedDevices(t, dir)); diff != "" {
+		t.Errorf("lockDevices kept locks diff (-want +got):\n%s", diff)
+	}
+
+	// Released while waiting.
+	time.AfterFunc(50*time.Millisecond, func() { BreakLock(dir, "dut1") })
+	locked, err := lockDevices(ctx, dir, testReservation(), 0, 5*time.Second)
+	if err != nil {
+		t.Fatalf("lockDevices got error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"ate1", "dut1"}, locked); diff != "" {
+		t.Errorf("lockDevices diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestBreakStale_TwoWaiters(t *testing.T) {
+	dir := t.TempDir()
+	writeLock(t, dir, &Lock{Device: "dut1", Host: "otherhost", PID: 1, Expires: time.Now().Add(-time.Minute)})
+	stale, err := os.ReadFile(lockPath(dir, "dut1"))
+	if err != nil {
+		t.Fatalf("cannot read lock: %v", err)
+	}
+
+	// Both waiters read the stale lock; the first breaks it and takes the
+	// device before the second tries to break it.
+	if err := breakStale(dir, "dut1", stale); err != nil {
+		t.Fatalf("first breakStale got error: %v", err)
+	}
+	if holder, err := tryLock(dir, newLock("dut1", time.Now(), time.Hour)); err != nil || holder != nil {
+		t.Fatalf("tryLock after breakStale got %v, %v, want the lock", holder, err)
+	}
+	if err := breakStale(dir, "dut1", stale); err != nil {
+		t.Fatalf("second breakStale got error: %v", err)
+	}
+	l, err := ReadLock(dir, "dut1")
+	if err != nil {
+		t.Fatalf("ReadLock got error: %v", err)
+	}
+	if l.PID != os.Getpid() {
+		t.Errorf("ReadLock got %v, want the lock of the first waiter kept", l)
+	}
+	if diff := cmp.Diff([]string{"dut1"}, lockedDevices(t, dir)); diff != "" {
+		t.Errorf("locks diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestBreakStale_LockedMeanwhile(t *testing.T) {
+	dir := t.TempDir()
+	writeLock(t, dir, &Lock{Device: "dut1", Host: "otherhost", PID: 1, Expires: time.Now().Add(-time.Minute)})
+	stale, err := os.ReadFile(lockPath(dir, "dut1"))
+	if err != nil {
+		t.Fatalf("cannot read lock: %v", err)
+	}
+	// The stale lock is replaced by a live one before it is set aside, and
+	// another run locks the device while the live lock is aside.
+	live := &Lock{Device: "dut1", Host: "host1", PID: 2, Expires: time.Now().Add(time.Hour)}
+	writeLock(t, dir, live)
+	defer func(f func()) { afterAside = f }(afterAside)
+	afterAside = func() {
+		writeLock(t, dir, &Lock{Device: "dut1", Host: "host2", PID: 3, Expires: time.Now().Add(time.Hour)})
+	}
+
+	if err := breakStale(dir, "dut1", stale); err == nil {
+		t.Errorf("breakStale got no error, want the device locked twice")
+	}
+	asides, err := filepath.Glob(lockPath(dir, "dut1") + ".broken-*")
+	if err != nil || len(asides) != 1 {
+		t.Fatalf("breakStale left %v, %v aside, want the live lock", asides, err)
+	}
+	data, err := os.ReadFile(asides[0])
+	if err != nil {
+		t.Fatalf("cannot read the lock aside: %v", err)
+	}
+	var got Lock
+	if err := json.Unmarshal(data, &got); err != nil || got.PID != live.PID {
+		t.Errorf("lock aside got %s, want the live lock %v", data, live)
+	}
+}
+
+func TestTryLock_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	writeLock(t, dir, &Lock{Device: "dut1", Host: "otherhost", PID: 1, Expires: time.Now().Add(-time.Minute)})
+
+	const waiters = 8
+	results := make(chan error, waiters)
+	var taken int32
+	var wg sync.WaitGroup
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			holder, err := tryLock(dir, newLock("dut1", time.Now(), time.Hour))
+			if err == nil && holder == nil {
+				atomic.AddInt32(&taken, 1)
+			}
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+	for err := range results {
+		if err != nil {
+			t.Errorf("tryLock got error: %v", err)
+		}
+	}
+	if taken != 1 {
+		t.Errorf("tryLock took the lock %d times, want 1", taken)
+	}
+}
+
+func TestRelease_IxSessionError(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	tb := linkedTestbed(2, opb.Port_SPEED_UNSPECIFIED)
+	sb := &staticBind{r: resolver{poolBinding(10, 10, 10, 10)}, lockDir: dir}
+	if _, err := sb.Reserve(ctx, tb, time.Hour, time.Second, nil); err != nil {
+		t.Fatalf("Reserve got error: %v", err)
+	}
+	// The ATE can no longer be resolved, so its sessions cannot be released.
+	sb.r = resolver{&bindpb.Binding{}}
+
+	if err := sb.Release(ctx); err == nil {
+		t.Errorf("Release got no error, want the IxNetwork session error")
+	}
+	if got := lockedDevices(t, dir); len(got) != 0 {
+		t.Errorf("Release left locks of %v", got)
+	}
+	if sb.resv != nil {
+		t.Errorf("Release kept the reservation")
+	}
+}
+
+func TestReserve_Lock(t *testing.T) {
+	defer func(poll time.Duration) { lockPoll = poll }(lockPoll)
+	lockPoll = 10 * time.Millisecond
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	tb := linkedTestbed(2, opb.Port_SPEED_UNSPECIFIED)
+	sb := &staticBind{r: resolver{poolBinding(10, 10, 10, 10)}, lockDir: dir}
+	if _, err := sb.Reserve(ctx, tb, time.Hour, time.Second, nil); err != nil {
+		t.Fatalf("Reserve got error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"ate1", "dut1"}, lockedDevices(t, dir)); diff != "" {
+		t.Errorf("Reserve locks diff (-want +got):\n%s", diff)
+	}
+
+	other := &staticBind{r: resolver{poolBinding(10, 10, 10, 10)}, lockDir: dir}
+	if _, err := other.Reserve(ctx, tb, time.Hour, 50*time.Millisecond, nil); err == nil {
+		t.Errorf("Reserve of locked devices got no error")
+	}
+
+	if err := sb.Release(ctx); err != nil {
+		t.Fatalf("Release got error: %v", err)
+	}
+	if got := lockedDevices(t, dir); len(got) != 0 {
+		t.Errorf("Release left locks of %v", got)
+	}
+}
@@ -33,6 +33,9 @@ var (
 	bindingFile = flag.String("binding", "", "static binding configuration file")
 	kneConfig   = flag.String("kne-config", "", "YAML configuration file")
 	pushConfig  = flag.Bool("push-config", true, "push device reset config supplied to static binding")
+	credsFile   = flag.String("credentials", "", "credentials file whose options are merged over the static binding, in the binding format")
+	lockDir     = flag.String("lock-dir", "", "directory of the advisory locks which a static binding takes on the reserved devices, shared by everyone using the lab; no locks if empty")
+	preflight   = flag.String("preflight", "", `probe the protocols of the devices reserved by a static binding: "report" records the health as run properties, "fail" also fails the reservation if a probe fails`)
 )
 
 // New creates a new binding that could be either a vendor plugin, a
@@ -93,8 +96,10 @@ func loadBinding(path, args string) (binding.Binding, error) {
 	return newFn(args)
 }
 
-// staticBinding makes a static binding from the binding configuration file.
-func staticBinding(bindingFile string) (binding.Binding, error) {
+// ReadBinding reads a static binding file.  If the credentials file is given,
+// its options are merged over those of the binding.  The environment variables
+// and file references in the options are then expanded.
+func ReadBinding(bindingFile, credsFile string) (*bindpb.Binding, error) {
 	in, err := os.ReadFile(bindingFile)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read binding file: %w", err)
@@ -103,14 +108,50 @@ func staticBinding(bindingFile string) (binding.Binding, error) {
 	if err := prototext.Unmarshal(in, b); err != nil {
 		return nil, fmt.Errorf("unable to parse binding file: %w", err)
 	}
-	for _, ate := range b.Ates {
-		if ate.Otg != nil && ate.Ixnetwork != nil {
-			return nil, fmt.Errorf("otg and ixnetwork are mutually exclusive, please configure one of them in ate %s binding", ate.Name)
+	if credsFile != "" {
+		creds, err := readCredentials(credsFile)
+		if err != nil {
+			return nil, err
 		}
+		if err := mergeCredentials(b, creds); err != nil {
+			return nil, err
+		}
+	}
+	if err := expandBinding(b); err != nil {
+		return nil, fmt.Errorf("unable to expand binding file: %w", err)
+	}
+	return b, nil
+}
+
+// staticBinding makes a static binding from the binding configuration file.
+func staticBinding(bindingFile string) (binding.Binding, error) {
+	b, err := ReadBinding(bindingFile, *credsFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(b); err != nil {
+		return nil, err
+	}
+	switch *preflight {
+	case preflightOff, preflightReport, preflightFail:
+	default:
+		return nil, fmt.Errorf("unknown -preflight %q, want %q or %q", *preflight, preflightReport, preflightFail)
 	}
 	return &staticBind{
-		Binding:    nil,
-		r:          resolver{b},
-		pushConfig: *pushConfig,
+		Binding:       nil,
+		r:             resolver{b},
+		pushConfig:    *pushConfig,
+		preflightMode: *preflight,
+		lockDir:       *lockDir,
 	}, nil
 }
+
+// validate checks the binding for options which conflict with each other.
+func validate(b *bindpb.Binding) error {
+	for _, ate := range b.Ates {
+		if ate.Otg != nil && ate.Ixnetwork != nil {
+			return fmt.Errorf("otg and ixnetwork are mutually exclusive, please configure one of them in ate %s binding", ate.Name)
+		}
+	}
+	return nil
+}
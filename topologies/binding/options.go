@@ -17,11 +17,15 @@ package binding
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
@@ -29,6 +33,7 @@ import (
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	"github.com/openconfig/ondatra/binding/ixweb"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/crypto/ssh/knownhosts"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -74,17 +79,62 @@ type dialer struct {
 	*bindpb.Options
 }
 
+// hasTLSConfig reports whether any option customizes the TLS config.
+func (d *dialer) hasTLSConfig() bool {
+	return d.SkipVerify || d.CertFile != "" || d.KeyFile != "" ||
+		d.TrustBundleFile != "" || d.TlsServerName != ""
+}
+
+// tlsConfig makes a tls.Config using the binding options, or returns nil if
+// no option customizes the TLS config.
+func (d *dialer) tlsConfig() (*tls.Config, error) {
+	if !d.hasTLSConfig() {
+		return nil, nil
+	}
+	c := &tls.Config{
+		InsecureSkipVerify: d.SkipVerify,
+		ServerName:         d.TlsServerName,
+	}
+	if d.CertFile != "" || d.KeyFile != "" {
+		if d.CertFile == "" || d.KeyFile == "" {
+			return nil, fmt.Errorf("cert_file and key_file must be set together, got cert_file=%q key_file=%q", d.CertFile, d.KeyFile)
+		}
+		cert, err := tls.LoadX509KeyPair(d.CertFile, d.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the client certificate: %w", err)
+		}
+		c.Certificates = []tls.Certificate{cert}
+	}
+	if d.TrustBundleFile != "" {
+		pem, err := os.ReadFile(d.TrustBundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the trust bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in the trust bundle %q", d.TrustBundleFile)
+		}
+		c.RootCAs = pool
+	}
+	return c, nil
+}
+
 // dialGRPC dials a gRPC connection using the binding options.
 //
 //lint:ignore U1000 will be used by the binding.
 func (d *dialer) dialGRPC(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	switch {
-	case d.Insecure:
+	if d.Insecure {
 		tc := insecure.NewCredentials()
 		opts = append(opts, grpc.WithTransportCredentials(tc))
-	case d.SkipVerify:
-		tc := credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
-		opts = append(opts, grpc.WithTransportCredentials(tc))
+	} else {
+		tlsConfig, err := d.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		if tlsConfig != nil {
+			tc := credentials.NewTLS(tlsConfig)
+			opts = append(opts, grpc.WithTransportCredentials(tc))
+		}
 	}
 	if d.Username != "" {
 		c := &creds{d.Username, d.Password, !d.Insecure}
@@ -122,45 +172,198 @@ func knownHostsCallback() (ssh.HostKeyCallback, error) {
 	return knownhosts.New(files...)
 }
 
-// dialSSH dials an SSH client using the binding options.
-//
-//lint:ignore U1000 will be used by the binding.
-func (d *dialer) dialSSH() (*ssh.Client, error) {
-	c := &ssh.ClientConfig{
-		User: d.Username,
-		Auth: []ssh.AuthMethod{ssh.Password(d.Password)},
+// knownHostsMu serializes the additions to the user known_hosts.
+var knownHostsMu sync.Mutex
+
+// tofuCallback checks the user and system SSH known_hosts like
+// knownHostsCallback, but trusts the key of an unknown host and adds it to the
+// user known_hosts, which is the first of knownHostsFiles.
+func tofuCallback() (ssh.HostKeyCallback, error) {
+	known, err := knownHostsCallback()
+	if err != nil {
+		return nil, err
 	}
-	if d.SkipVerify {
-		c.HostKeyCallback = ssh.InsecureIgnoreHostKey()
-	} else {
-		cb, err := knownHostsCallback()
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := known(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) || len(keyErr.Want) > 0 {
+			return err
+		}
+		// The host is unknown, rather than known with a different key.
+		line := knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key)
+		if err := appendKnownHost(os.ExpandEnv(knownHostsFiles[0]), line); err != nil {
+			return fmt.Errorf("cannot trust the host key of %s: %w", hostname, err)
+		}
+		return nil
+	}, nil
+}
+
+// appendKnownHost appends the line to the known_hosts file, creating the file
+// and its directory if missing.
+func appendKnownHost(file, line string) error {
+	knownHostsMu.Lock()
+	defer knownHostsMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// sshHostKeyCallback returns the host key callback of the binding options.
+func (d *dialer) sshHostKeyCallback() (ssh.HostKeyCallback, error) {
+	switch {
+	case d.SkipVerify:
+		return ssh.InsecureIgnoreHostKey(), nil
+	case d.SshTrustOnFirstUse:
+		return tofuCallback()
+	default:
+		return knownHostsCallback()
+	}
+}
+
+// sshAuth returns the SSH authentication methods of the binding options, in
+// the order they are tried: the private key, the ssh-agent, keyboard-interactive
+// and the password.  The returned func releases the ssh-agent connection.
+func (d *dialer) sshAuth() ([]ssh.AuthMethod, func(), error) {
+	var methods []ssh.AuthMethod
+	if d.SshPrivateKeyFile != "" {
+		signer, err := d.sshSigner()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	cleanup := func() {}
+	if d.SshAgent {
+		sock := os.Getenv("SSH_AUTH_SOCK")
+		if sock == "" {
+			return nil, nil, errors.New("ssh_agent is set but SSH_AUTH_SOCK is not")
 		}
-		c.HostKeyCallback = cb
+		conn, err := net.Dial("unix", sock)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot connect to the ssh-agent: %w", err)
+		}
+		methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		cleanup = func() { conn.Close() }
+	}
+	if d.SshKeyboardInteractive {
+		methods = append(methods, ssh.KeyboardInteractive(keyboardInteractive(d.Password)))
 	}
-	return ssh.Dial("tcp", d.Target, c)
+	if d.Password != "" || len(methods) == 0 {
+		methods = append(methods, ssh.Password(d.Password))
+	}
+	return methods, cleanup, nil
+}
+
+// sshSigner loads the private key of the binding options.
+func (d *dialer) sshSigner() (ssh.Signer, error) {
+	pem, err := os.ReadFile(d.SshPrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the SSH private key: %w", err)
+	}
+	var signer ssh.Signer
+	if d.SshPrivateKeyPassphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(pem, []byte(d.SshPrivateKeyPassphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(pem)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the SSH private key %q: %w", d.SshPrivateKeyFile, err)
+	}
+	return signer, nil
+}
+
+// keyboardInteractive answers the password to every question.
+func keyboardInteractive(password string) ssh.KeyboardInteractiveChallenge {
+	return func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+		for i := range answers {
+			answers[i] = password
+		}
+		return answers, nil
+	}
+}
+
+// dialSSH dials an SSH client using the binding options.  The context bounds
+// the connection and the SSH handshake, but not the client once dialed.
+func (d *dialer) dialSSH(ctx context.Context) (*ssh.Client, error) {
+	auth, cleanup, err := d.sshAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	cb, err := d.sshHostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+	c := &ssh.ClientConfig{
+		User:            d.Username,
+		Auth:            auth,
+		HostKeyCallback: cb,
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", d.Target)
+	if err != nil {
+		return nil, err
+	}
+	// Interrupt the handshake when the context is done.
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+	sconn, chans, reqs, err := ssh.NewClientConn(conn, d.Target, c)
+	close(done)
+	<-stopped
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("ssh dial %s: %w", d.Target, ctx.Err())
+		}
+		return nil, err
+	}
+	return ssh.NewClient(sconn, chans, reqs), nil
 }
 
 // newHTTPClient makes an http.Client using the binding options.
 //
 //lint:ignore U1000 will be used by the binding.
-func (d *dialer) newHTTPClient() *http.Client {
+func (d *dialer) newHTTPClient() (*http.Client, error) {
+	tlsConfig, err := d.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
 	tr := &http.Transport{
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
+		TLSClientConfig: tlsConfig,
 	}
-	if d.SkipVerify {
-		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	}
-	return &http.Client{Transport: tr}
+	return &http.Client{Transport: tr}, nil
 }
 
 // newIxWebClient makes an IxWeb session using the binding options.
 func (d *dialer) newIxWebClient(ctx context.Context) (*ixweb.IxWeb, error) {
-	hc := d.newHTTPClient()
+	hc, err := d.newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
 	username := d.GetUsername()
 	password := d.GetPassword()
 	if username == "" && password == "" {
@@ -15,12 +15,40 @@
 package binding
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 )
 
@@ -83,6 +111,23 @@ func TestMerge(t *testing.T) {
 			Username:   "username2",
 			Password:   "password3",
 		},
+	}, {
+		name: "TLS",
+		args: []*bindpb.Options{{
+			CertFile:        "global.crt",
+			KeyFile:         "global.key",
+			TrustBundleFile: "global.pem",
+		}, {
+			CertFile:      "dut.crt",
+			KeyFile:       "dut.key",
+			TlsServerName: "dut.example",
+		}},
+		want: &bindpb.Options{
+			CertFile:        "dut.crt",
+			KeyFile:         "dut.key",
+			TrustBundleFile: "global.pem",
+			TlsServerName:   "dut.example",
+		},
 	}}
 
 	for _, c := range cases {
@@ -372,3 +417,581 @@ func TestResolver_ByName_Protocols_Error(t *testing.T) {
 		})
 	}
 }
+
+// testPKI is a locally generated CA with a server and a client certificate
+// that it issued, written as PEM files.
+type testPKI struct {
+	caFile                string
+	serverCert            tls.Certificate
+	clientCert, clientKey string
+	serverName            string
+	otherCAFile           string
+	caPool                *x509.CertPool
+}
+
+// newTestPKI generates the CA and the certificates in a temporary directory.
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	dir := t.TempDir()
+	p := &testPKI{serverName: "dut.example"}
+
+	ca, caKey, caPEM := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	p.caFile = writeTestFile(t, dir, "ca.pem", caPEM)
+	p.caPool = x509.NewCertPool()
+	p.caPool.AddCert(ca)
+
+	_, _, otherPEM := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "other CA"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	p.otherCAFile = writeTestFile(t, dir, "other.pem", otherPEM)
+
+	_, serverKey, serverPEM := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: p.serverName},
+		DNSNames:    []string{p.serverName},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	serverCert, err := tls.X509KeyPair(serverPEM, keyPEM(t, serverKey))
+	if err != nil {
+		t.Fatalf("Cannot load the server certificate: %v", err)
+	}
+	p.serverCert = serverCert
+
+	_, clientKey, clientPEM := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "client"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	p.clientCert = writeTestFile(t, dir, "client.crt", clientPEM)
+	p.clientKey = writeTestFile(t, dir, "client.key", keyPEM(t, clientKey))
+	return p
+}
+
+// newTestCert generates a certificate from the template, signed by the parent,
+// or self-signed if the parent is nil.
+func newTestCert(t *testing.T, tmpl, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Cannot generate a key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("Cannot generate a serial number: %v", err)
+	}
+	tmpl.SerialNumber = serial
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Cannot create the certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Cannot parse the certificate: %v", err)
+	}
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func keyPEM(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Cannot marshal the key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func writeTestFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		t.Fatalf("Cannot write %s: %v", file, err)
+	}
+	return file
+}
+
+// newMTLSServer starts an HTTPS server which requires a client certificate
+// issued by the CA.
+func newMTLSServer(t *testing.T, p *testPKI) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{p.serverCert},
+		ClientCAs:    p.caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTLSConfig(t *testing.T) {
+	p := newTestPKI(t)
+
+	t.Run("None", func(t *testing.T) {
+		d := dialer{&bindpb.Options{Username: "username"}}
+		c, err := d.tlsConfig()
+		if err != nil {
+			t.Fatalf("tlsConfig got error: %v", err)
+		}
+		if c != nil {
+			t.Errorf("tlsConfig got %v, want nil", c)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		d := dialer{&bindpb.Options{
+			CertFile:        p.clientCert,
+			KeyFile:         p.clientKey,
+			TrustBundleFile: p.caFile,
+			TlsServerName:   p.serverName,
+		}}
+		c, err := d.tlsConfig()
+		if err != nil {
+			t.Fatalf("tlsConfig got error: %v", err)
+		}
+		if got := len(c.Certificates); got != 1 {
+			t.Errorf("tlsConfig got %d certificates, want 1", got)
+		}
+		if c.RootCAs == nil {
+			t.Errorf("tlsConfig got no root CAs, want the trust bundle")
+		}
+		if c.ServerName != p.serverName {
+			t.Errorf("tlsConfig server name got %q, want %q", c.ServerName, p.serverName)
+		}
+		if c.InsecureSkipVerify {
+			t.Errorf("tlsConfig got InsecureSkipVerify, want verification")
+		}
+	})
+
+	errCases := []struct {
+		name string
+		opts *bindpb.Options
+	}{{
+		name: "CertWithoutKey",
+		opts: &bindpb.Options{CertFile: p.clientCert},
+	}, {
+		name: "KeyWithoutCert",
+		opts: &bindpb.Options{KeyFile: p.clientKey},
+	}, {
+		name: "MissingCert",
+		opts: &bindpb.Options{CertFile: "/no/such/file", KeyFile: p.clientKey},
+	}, {
+		name: "MissingTrustBundle",
+		opts: &bindpb.Options{TrustBundleFile: "/no/such/file"},
+	}, {
+		name: "BadTrustBundle",
+		opts: &bindpb.Options{TrustBundleFile: p.clientKey},
+	}}
+	for _, c := range errCases {
+		t.Run(c.name, func(t *testing.T) {
+			d := dialer{c.opts}
+			if _, err := d.tlsConfig(); err == nil {
+				t.Errorf("tlsConfig got nil error, want error")
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_MTLS(t *testing.T) {
+	p := newTestPKI(t)
+	srv := newMTLSServer(t, p)
+
+	cases := []struct {
+		name    string
+		opts    *bindpb.Options
+		wantErr bool
+	}{{
+		name: "MutualTLS",
+		opts: &bindpb.Options{
+			CertFile:        p.clientCert,
+			KeyFile:         p.clientKey,
+			TrustBundleFile: p.caFile,
+			TlsServerName:   p.serverName,
+		},
+	}, {
+		name: "SkipVerify",
+		opts: &bindpb.Options{
+			CertFile:   p.clientCert,
+			KeyFile:    p.clientKey,
+			SkipVerify: true,
+		},
+	}, {
+		name: "NoClientCert",
+		opts: &bindpb.Options{
+			TrustBundleFile: p.caFile,
+			TlsServerName:   p.serverName,
+		},
+		wantErr: true,
+	}, {
+		name: "WrongServerName",
+		opts: &bindpb.Options{
+			CertFile:        p.clientCert,
+			KeyFile:         p.clientKey,
+			TrustBundleFile: p.caFile,
+			TlsServerName:   "other.example",
+		},
+		wantErr: true,
+	}, {
+		name: "UntrustedServer",
+		opts: &bindpb.Options{
+			CertFile:        p.clientCert,
+			KeyFile:         p.clientKey,
+			TrustBundleFile: p.otherCAFile,
+			TlsServerName:   p.serverName,
+		},
+		wantErr: true,
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := dialer{c.opts}
+			hc, err := d.newHTTPClient()
+			if err != nil {
+				t.Fatalf("newHTTPClient got error: %v", err)
+			}
+			resp, err := hc.Get(srv.URL)
+			if gotErr := err != nil; gotErr != c.wantErr {
+				t.Fatalf("Get got error %v, want error %v", err, c.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Cannot read the response: %v", err)
+			}
+			if got, want := string(body), "client"; got != want {
+				t.Errorf("Server saw client %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDialGRPC_MTLS(t *testing.T) {
+	p := newTestPKI(t)
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{p.serverCert},
+		ClientCAs:    p.caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	d := dialer{&bindpb.Options{
+		Target:          lis.Addr().String(),
+		CertFile:        p.clientCert,
+		KeyFile:         p.clientKey,
+		TrustBundleFile: p.caFile,
+		TlsServerName:   p.serverName,
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := d.dialGRPC(ctx)
+	if err != nil {
+		t.Fatalf("dialGRPC got error: %v", err)
+	}
+	defer conn.Close()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Health check got error: %v", err)
+	}
+	if got, want := resp.GetStatus(), healthpb.HealthCheckResponse_SERVING; got != want {
+		t.Errorf("Health check got %v, want %v", got, want)
+	}
+}
+
+func newTestSigner(t *testing.T) (ssh.Signer, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Cannot generate a key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatalf("Cannot make a signer: %v", err)
+	}
+	return signer, key
+}
+
+// newSSHServer starts an SSH server which accepts the client key, or the
+// password by keyboard-interactive or password authentication, depending on
+// the arguments.  It returns the address of the server.
+func newSSHServer(t *testing.T, hostKey ssh.Signer, clientKey ssh.PublicKey, kbdPassword, password string) string {
+	t.Helper()
+	config := &ssh.ServerConfig{}
+	if clientKey != nil {
+		config.PublicKeyCallback = func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("wrong key")
+		}
+	}
+	if kbdPassword != "" {
+		config.KeyboardInteractiveCallback = func(_ ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			answers, err := client("", "", []string{"Password: ", "OTP: "}, []bool{false, false})
+			if err != nil {
+				return nil, err
+			}
+			if len(answers) == 2 && answers[0] == kbdPassword && answers[1] == kbdPassword {
+				return nil, nil
+			}
+			return nil, errors.New("wrong answers")
+		}
+	}
+	if password != "" {
+		config.PasswordCallback = func(_ ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if string(pass) == password {
+				return nil, nil
+			}
+			return nil, errors.New("wrong password")
+		}
+	}
+	config.AddHostKey(hostKey)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				sc, chans, reqs, err := ssh.NewServerConn(conn, config)
+				if err != nil {
+					return
+				}
+				defer sc.Close()
+				go ssh.DiscardRequests(reqs)
+				for ch := range chans {
+					ch.Reject(ssh.Prohibited, "no channels")
+				}
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// serveTestAgent serves an ssh-agent with the key, and points SSH_AUTH_SOCK to
+// it.
+func serveTestAgent(t *testing.T, key *ecdsa.PrivateKey) {
+	t.Helper()
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: key}); err != nil {
+		t.Fatalf("Cannot add the key to the agent: %v", err)
+	}
+	sock := filepath.Join(t.TempDir(), "agent.sock")
+	lis, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+	t.Setenv("SSH_AUTH_SOCK", sock)
+}
+
+func TestDialSSH(t *testing.T) {
+	hostKey, _ := newTestSigner(t)
+	clientKey, clientECKey := newTestSigner(t)
+	otherKey, _ := newTestSigner(t)
+	dir := t.TempDir()
+	keyFile := writeTestFile(t, dir, "id_ecdsa", keyPEM(t, clientECKey))
+	serveTestAgent(t, clientECKey)
+
+	cases := []struct {
+		name                  string
+		clientKey             ssh.PublicKey
+		kbdPassword, password string
+		opts                  *bindpb.Options
+		wantErr               bool
+	}{{
+		name:      "PrivateKey",
+		clientKey: clientKey.PublicKey(),
+		opts:      &bindpb.Options{SshPrivateKeyFile: keyFile},
+	}, {
+		name:      "WrongPrivateKey",
+		clientKey: otherKey.PublicKey(),
+		opts:      &bindpb.Options{SshPrivateKeyFile: keyFile},
+		wantErr:   true,
+	}, {
+		name:      "Agent",
+		clientKey: clientKey.PublicKey(),
+		opts:      &bindpb.Options{SshAgent: true},
+	}, {
+		name:        "KeyboardInteractive",
+		kbdPassword: "password",
+		opts:        &bindpb.Options{SshKeyboardInteractive: true, Password: "password"},
+	}, {
+		name:        "KeyboardInteractiveNotEnabled",
+		kbdPassword: "password",
+		opts:        &bindpb.Options{Password: "password"},
+		wantErr:     true,
+	}, {
+		name:     "Password",
+		password: "password",
+		opts:     &bindpb.Options{Password: "password"},
+	}, {
+		name:      "PasswordAfterKey",
+		clientKey: otherKey.PublicKey(),
+		password:  "password",
+		opts:      &bindpb.Options{SshPrivateKeyFile: keyFile, Password: "password"},
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			addr := newSSHServer(t, hostKey, c.clientKey, c.kbdPassword, c.password)
+			opts := proto.Clone(c.opts).(*bindpb.Options)
+			opts.Target = addr
+			opts.Username = "admin"
+			opts.SkipVerify = true
+			d := dialer{opts}
+			sc, err := d.dialSSH(context.Background())
+			if gotErr := err != nil; gotErr != c.wantErr {
+				t.Fatalf("dialSSH got error %v, want error %v", err, c.wantErr)
+			}
+			if sc != nil {
+				sc.Close()
+			}
+		})
+	}
+}
+
+func TestDialSSH_Timeout(t *testing.T) {
+	// The server accepts the connection but never answers the handshake.
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		close(done)
+		lis.Close()
+	})
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		<-done
+		conn.Close()
+	}()
+
+	d := dialer{&bindpb.Options{Target: lis.Addr().String(), Username: "admin", Password: "password", SkipVerify: true}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	sc, err := d.dialSSH(ctx)
+	if err == nil {
+		sc.Close()
+		t.Fatalf("dialSSH got no error, want the context deadline")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("dialSSH got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("dialSSH returned after %v, want it bounded by the context", d)
+	}
+}
+
+func TestSSHAuth_Error(t *testing.T) {
+	dir := t.TempDir()
+	badKey := writeTestFile(t, dir, "bad", []byte("not a key"))
+	cases := []struct {
+		name string
+		opts *bindpb.Options
+		sock string
+	}{{
+		name: "MissingKey",
+		opts: &bindpb.Options{SshPrivateKeyFile: filepath.Join(dir, "missing")},
+	}, {
+		name: "BadKey",
+		opts: &bindpb.Options{SshPrivateKeyFile: badKey},
+	}, {
+		name: "NoAgent",
+		opts: &bindpb.Options{SshAgent: true},
+	}, {
+		name: "AgentNotListening",
+		opts: &bindpb.Options{SshAgent: true},
+		sock: filepath.Join(dir, "missing.sock"),
+	}}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv("SSH_AUTH_SOCK", c.sock)
+			d := dialer{c.opts}
+			if _, _, err := d.sshAuth(); err == nil {
+				t.Errorf("sshAuth got nil error, want error")
+			}
+		})
+	}
+}
+
+func TestTOFUCallback(t *testing.T) {
+	knownHosts := filepath.Join(t.TempDir(), "ssh", "known_hosts")
+	oldFiles := knownHostsFiles
+	knownHostsFiles = []string{knownHosts}
+	t.Cleanup(func() { knownHostsFiles = oldFiles })
+
+	hostKey, _ := newTestSigner(t)
+	otherKey, _ := newTestSigner(t)
+	addr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 22}
+	check := func(key ssh.PublicKey) error {
+		cb, err := tofuCallback()
+		if err != nil {
+			t.Fatalf("tofuCallback got error: %v", err)
+		}
+		return cb("dut.example:22", addr, key)
+	}
+
+	if err := check(hostKey.PublicKey()); err != nil {
+		t.Fatalf("Unknown host got error %v, want trusted", err)
+	}
+	data, err := os.ReadFile(knownHosts)
+	if err != nil {
+		t.Fatalf("Cannot read known_hosts: %v", err)
+	}
+	want := knownhosts.Line([]string{"dut.example"}, hostKey.PublicKey()) + "\n"
+	if diff := cmp.Diff(want, string(data)); diff != "" {
+		t.Errorf("known_hosts diff (-want +got):\n%s", diff)
+	}
+	if err := check(hostKey.PublicKey()); err != nil {
+		t.Errorf("Known host got error %v, want trusted", err)
+	}
+	if err := check(otherKey.PublicKey()); err == nil {
+		t.Errorf("Changed host key got nil error, want error")
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/open-traffic-generator/snappi/gosnappi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	spb "github.com/openconfig/gnoi/system"
+	authzpb "github.com/openconfig/gnsi/authz"
+	grpb "github.com/openconfig/gribi/v1/proto/service"
+	p4pb "github.com/p4lang/p4runtime/go/p4/v1"
+)
+
+// Values of the -preflight flag.
+const (
+	preflightOff    = ""
+	preflightReport = "report"
+	preflightFail   = "fail"
+)
+
+// preflightTimeout bounds each preflight probe.
+var preflightTimeout = 30 * time.Second
+
+// probe is a preflight check of a protocol of a device.
+type probe struct {
+	device, protocol string
+	check            func(ctx context.Context) error
+}
+
+// probeResult is the outcome of a probe.
+type probeResult struct {
+	device, protocol string
+	err              error
+}
+
+// preflight probes the protocols of the reserved devices.  The results are
+// logged as a health matrix and recorded as run properties.  In the fail mode,
+// a failed probe also fails the reservation.
+func (b *staticBind) preflight(ctx context.Context) error {
+	if b.preflightMode == preflightOff {
+		return nil
+	}
+	results := runProbes(ctx, b.probes())
+	glog.Infof("Preflight health of the reserved devices:\n%s", healthMatrix(results))
+	for k, v := range probeProperties(results) {
+		addSuiteProperty(k, v)
+	}
+	if b.preflightMode != preflightFail {
+		return nil
+	}
+	var errs allerrors
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", r.device, r.protocol, r.err))
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("preflight failed:\n%s\n%w", healthMatrix(results), errs)
+	}
+	return nil
+}
+
+// probes returns the probes of the reserved devices.  A DUT is probed for
+// gNMI, for gNOI, gNSI, gRIBI and P4RT if the binding has options for them,
+// and for SSH if the binding has SSH options or a CLI reset config.  An ATE is
+// probed for OTG and its gNMI if it uses OTG; IxNetwork is checked by the
+// session reservation already.
+func (b *staticBind) probes() []*probe {
+	var probes []*probe
+	add := func(device, protocol string, resolve func(string) (dialer, error), rpc func(context.Context, *grpc.ClientConn) error) {
+		probes = append(probes, &probe{
+			device:   device,
+			protocol: protocol,
+			check: func(ctx context.Context) error {
+				d, err := resolve(device)
+				if err != nil {
+					return err
+				}
+				return probeGRPC(ctx, d, rpc)
+			},
+		})
+	}
+	for _, dut := range b.resv.DUTs {
+		sdut, ok := dut.(*staticDUT)
+		if !ok {
+			continue
+		}
+		name, dev := sdut.Name(), sdut.dev
+		add(name, "gnmi", b.r.gnmi, probeGNMI)
+		if dev.Gnoi != nil {
+			add(name, "gnoi", b.r.gnoi, probeGNOI)
+		}
+		if dev.Gnsi != nil {
+			add(name, "gnsi", b.r.gnsi, probeGNSI)
+		}
+		if dev.Ssh != nil || hasResetCLI(dev) {
+			probes = append(probes, &probe{
+				device:   name,
+				protocol: "ssh",
+				check: func(ctx context.Context) error {
+					d, err := b.r.ssh(name)
+					if err != nil {
+						return err
+					}
+					return probeSSH(ctx, d)
+				},
+			})
+		}
+		if dev.Gribi != nil || dev.GetConfig().GetGribiFlush() {
+			add(name, "gribi", b.r.gribi, probeGRIBI)
+		}
+		if dev.P4Rt != nil {
+			add(name, "p4rt", b.r.p4rt, probeP4RT)
+		}
+	}
+	for _, ate := range b.resv.ATEs {
+		sate, ok := ate.(*staticATE)
+		if !ok || sate.dev.Otg == nil {
+			continue
+		}
+		name := sate.Name()
+		add(name, "otg", b.r.ateOtg, probeOTG)
+		add(name, "gnmi", b.r.ateGNMI, probeGNMI)
+	}
+	sort.Slice(probes, func(i, j int) bool {
+		if probes[i].device != probes[j].device {
+			return probes[i].device < probes[j].device
+		}
+		return probes[i].protocol < probes[j].protocol
+	})
+	return probes
+}
+
+// runProbes runs the probes concurrently, each within preflightTimeout.
+func runProbes(ctx context.Context, probes []*probe) []*probeResult {
+	results := make([]*probeResult, len(probes))
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func(i int, p *probe) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+			defer cancel()
+			results[i] = &probeResult{device: p.device, protocol: p.protocol, err: p.check(ctx)}
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
+
+// probeGRPC dials the dialer and calls the rpc on the connection.
+func probeGRPC(ctx context.Context, d dialer, rpc func(context.Context, *grpc.ClientConn) error) error {
+	conn, err := d.dialGRPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return rpc(ctx, conn)
+}
+
+func probeGNMI(ctx context.Context, conn *grpc.ClientConn) error {
+	_, err := gpb.NewGNMIClient(conn).Capabilities(ctx, &gpb.CapabilityRequest{})
+	return err
+}
+
+func probeGNOI(ctx context.Context, conn *grpc.ClientConn) error {
+	_, err := spb.NewSystemClient(conn).Time(ctx, &spb.TimeRequest{})
+	return err
+}
+
+// probeGNSI gets the authz policy, which a device without a policy may
+// answer with NotFound.
+func probeGNSI(ctx context.Context, conn *grpc.ClientConn) error {
+	_, err := authzpb.NewAuthzClient(conn).Get(ctx, &authzpb.GetRequest{})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+// probeSSH dials an SSH client, which authenticates it.
+func probeSSH(ctx context.Context, d dialer) error {
+	sc, err := d.dialSSH(ctx)
+	if err != nil {
+		return err
+	}
+	return sc.Close()
+}
+
+// hasResetCLI reports whether the reset config of the device has CLI configs,
+// which are sent over SSH.
+func hasResetCLI(dev *bindpb.Device) bool {
+	c := dev.GetConfig()
+	if len(c.GetCli()) > 0 || len(c.GetCliFile()) > 0 {
+		return true
+	}
+	for _, step := range c.GetSteps() {
+		switch step.GetConfig().(type) {
+		case *bindpb.ResetStep_Cli, *bindpb.ResetStep_CliFile:
+			return true
+		}
+	}
+	return false
+}
+
+// probeGRIBI reads the first response of a Get of all the AFTs.
+func probeGRIBI(ctx context.Context, conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := grpb.NewGRIBIClient(conn).Get(ctx, &grpb.GetRequest{
+		NetworkInstance: &grpb.GetRequest_All{All: &grpb.Empty{}},
+		Aft:             grpb.AFTType_ALL,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := stream.Recv(); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func probeP4RT(ctx context.Context, conn *grpc.ClientConn) error {
+	_, err := p4pb.NewP4RuntimeClient(conn).Capabilities(ctx, &p4pb.CapabilitiesRequest{})
+	return err
+}
+
+// probeOTG gets the config of the OTG.  This version of the OTG API has no
+// GetVersion.
+func probeOTG(ctx context.Context, conn *grpc.ClientConn) error {
+	api := gosnappi.NewApi()
+	api.NewGrpcTransport().SetClientConnection(conn).SetRequestTimeout(preflightTimeout)
+	_, err := api.GetConfig()
+	return err
+}
+
+// healthMatrix formats the results as a table of the devices by protocol.
+func healthMatrix(results []*probeResult) string {
+	var devices, protocols []string
+	cells := make(map[[2]string]string)
+	for _, r := range results {
+		if !contains(devices, r.device) {
+			devices = append(devices, r.device)
+		}
+		if !contains(protocols, r.protocol) {
+			protocols = append(protocols, r.protocol)
+		}
+		cell := "ok"
+		if r.err != nil {
+			cell = "FAIL"
+		}
+		cells[[2]string{r.device, r.protocol}] = cell
+	}
+	sort.Strings(protocols)
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "DEVICE\t%s\n", strings.Join(protocols, "\t"))
+	for _, dev := range devices {
+		row := []string{dev}
+		for _, proto := range protocols {
+			cell, ok := cells[[2]string{dev, proto}]
+			if !ok {
+				cell = "-"
+			}
+			row = append(row, cell)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Fprintf(&b, "%s %s: %v\n", r.device, r.protocol, r.err)
+		}
+	}
+	return b.String()
+}
+
+// probeProperties returns the results as run properties, keyed by
+// "preflight.<device>.<protocol>" with the value "ok" or the error.
+func probeProperties(results []*probeResult) map[string]string {
+	m := make(map[string]string)
+	for _, r := range results {
+		v := "ok"
+		if r.err != nil {
+			v = r.err.Error()
+		}
+		m[fmt.Sprintf("preflight.%s.%s", r.device, r.protocol)] = v
+	}
+	return m
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/grpc"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// fakeGNMI answers Capabilities.
+type fakeGNMI struct {
+	gpb.UnimplementedGNMIServer
+}
+
+func (fakeGNMI) Capabilities(context.Context, *gpb.CapabilityRequest) (*gpb.CapabilityResponse, error) {
+	return &gpb.CapabilityResponse{GNMIVersion: "0.8.0"}, nil
+}
+
+func newFakeGNMI(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	gpb.RegisterGNMIServer(srv, fakeGNMI{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+// closedAddr returns an address with nothing listening.
+func closedAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func TestPreflight(t *testing.T) {
+	oldTimeout := preflightTimeout
+	preflightTimeout = 5 * time.Second
+	t.Cleanup(func() { preflightTimeout = oldTimeout })
+
+	props := make(map[string]string)
+	oldAdd := addSuiteProperty
+	addSuiteProperty = func(k, v string) { props[k] = v }
+	t.Cleanup(func() { addSuiteProperty = oldAdd })
+
+	gnmiAddr := newFakeGNMI(t)
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut1"}, {Id: "dut2"}}}
+	b := &bindpb.Binding{
+		Options: &bindpb.Options{Insecure: true},
+		Duts: []*bindpb.Device{{
+			Id:    "dut1",
+			Name:  "dut1.name",
+			Gnmi:  &bindpb.Options{Target: gnmiAddr},
+			Gribi: &bindpb.Options{Target: closedAddr(t)},
+		}, {
+			Id:   "dut2",
+			Name: "dut2.name",
+			Gnmi: &bindpb.Options{Target: gnmiAddr},
+			Gnsi: &bindpb.Options{Target: closedAddr(t)},
+			Ssh:  &bindpb.Options{Target: closedAddr(t)},
+		}},
+	}
+	resv, err := reservation(tb, resolver{b})
+	if err != nil {
+		t.Fatalf("Error building reservation: %v", err)
+	}
+
+	ctx := context.Background()
+	sb := &staticBind{r: resolver{b}, resv: resv, preflightMode: preflightReport}
+	if err := sb.preflight(ctx); err != nil {
+		t.Errorf("preflight in report mode got error: %v", err)
+	}
+	if got, want := props["preflight.dut1.name.gnmi"], "ok"; got != want {
+		t.Errorf("dut1 gnmi property got %q, want %q", got, want)
+	}
+	if got, want := props["preflight.dut2.name.gnmi"], "ok"; got != want {
+		t.Errorf("dut2 gnmi property got %q, want %q", got, want)
+	}
+	if got := props["preflight.dut1.name.gribi"]; got == "" || got == "ok" {
+		t.Errorf("dut1 gribi property got %q, want the error", got)
+	}
+	if _, ok := props["preflight.dut2.name.gribi"]; ok {
+		t.Errorf("dut2 gribi is probed without gribi options")
+	}
+	for _, protocol := range []string{"gnsi", "ssh"} {
+		if got := props["preflight.dut2.name."+protocol]; got == "" || got == "ok" {
+			t.Errorf("dut2 %s property got %q, want the error", protocol, got)
+		}
+		if _, ok := props["preflight.dut1.name."+protocol]; ok {
+			t.Errorf("dut1 %s is probed without %s options", protocol, protocol)
+		}
+	}
+
+	sb.preflightMode = preflightFail
+	err = sb.preflight(ctx)
+	if err == nil {
+		t.Fatalf("preflight in fail mode got nil error, want the gribi error")
+	}
+	if !strings.Contains(err.Error(), "dut1.name gribi") {
+		t.Errorf("preflight got error %q, want it to name dut1.name gribi", err)
+	}
+
+	sb.preflightMode = preflightOff
+	props = make(map[string]string)
+	if err := sb.preflight(ctx); err != nil || len(props) != 0 {
+		t.Errorf("preflight off got error %v and properties %v, want neither", err, props)
+	}
+}
+
+func TestHealthMatrix(t *testing.T) {
+	results := []*probeResult{
+		{device: "dut1", protocol: "gnmi"},
+		{device: "dut1", protocol: "gribi", err: errors.New("unavailable")},
+		{device: "dut2", protocol: "gnmi"},
+		{device: "ate", protocol: "otg"},
+	}
+	want := `DEVICE  gnmi  gribi  otg
+dut1    ok    FAIL   -
+dut2    ok    -      -
+ate     -     -      ok
+dut1 gribi: unavailable
+`
+	if diff := cmp.Diff(want, healthMatrix(results)); diff != "" {
+		t.Errorf("healthMatrix diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestHasResetCLI(t *testing.T) {
+	tests := []struct {
+		desc string
+		c    *bindpb.Configs
+		want bool
+	}{
+		{"none", nil, false},
+		{"gnmi", &bindpb.Configs{GnmiSetFile: []string{"set.textproto"}}, false},
+		{"cli", &bindpb.Configs{Cli: [][]byte{[]byte("hostname dut")}}, true},
+		{"cli file", &bindpb.Configs{CliFile: []string{"dut.cfg"}}, true},
+		{"cli step", &bindpb.Configs{Steps: []*bindpb.ResetStep{
+			{Config: &bindpb.ResetStep_GribiFlush{GribiFlush: true}},
+			{Config: &bindpb.ResetStep_CliFile{CliFile: "dut.cfg"}},
+		}}, true},
+	}
+	for _, tt := range tests {
+		if got := hasResetCLI(&bindpb.Device{Config: tt.c}); got != tt.want {
+			t.Errorf("hasResetCLI(%s) got %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
@@ -15,14 +15,22 @@
 package binding
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
 	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	spb "github.com/openconfig/gribi/v1/proto/service"
+	"github.com/openconfig/ygot/ygot"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
 )
 
 func readCLI(path string) (string, error) {
@@ -50,12 +58,16 @@ func resetCLI(ctx context.Context, bdut *bindpb.Device, r resolver) error {
 	if conf == "" {
 		return nil
 	}
+	return sendCLI(ctx, bdut, r, conf)
+}
 
+// sendCLI sends the raw config to the device.
+func sendCLI(ctx context.Context, bdut *bindpb.Device, r resolver, conf string) error {
 	dialer, err := r.ssh(bdut.GetName())
 	if err != nil {
 		return err
 	}
-	sc, err := dialer.dialSSH()
+	sc, err := dialer.dialSSH(ctx)
 	if err != nil {
 		return err
 	}
@@ -96,7 +108,11 @@ func resetGNMI(ctx context.Context, bdut *bindpb.Device, r resolver) error {
 	if len(setReq) == 0 {
 		return nil
 	}
+	return setGNMI(ctx, bdut, r, setReq...)
+}
 
+// setGNMI sends the SetRequests to the device in order.
+func setGNMI(ctx context.Context, bdut *bindpb.Device, r resolver, setReq ...*gpb.SetRequest) error {
 	dialer, err := r.gnmi(bdut.GetName())
 	if err != nil {
 		return err
@@ -121,7 +137,11 @@ func resetGRIBI(ctx context.Context, bdut *bindpb.Device, r resolver) error {
 	if !bdut.GetConfig().GetGribiFlush() {
 		return nil
 	}
+	return flushGRIBI(ctx, bdut, r)
+}
 
+// flushGRIBI flushes all the network instances of the device.
+func flushGRIBI(ctx context.Context, bdut *bindpb.Device, r resolver) error {
 	dialer, err := r.gribi(bdut.GetName())
 	if err != nil {
 		return err
@@ -144,3 +164,258 @@ func resetGRIBI(ctx context.Context, bdut *bindpb.Device, r resolver) error {
 	_, err = gribi.Flush(ctx, req)
 	return err
 }
+
+var (
+	// resetStepTimeout is the timeout of a reset step which sets none.
+	resetStepTimeout = 5 * time.Minute
+
+	// verifyPoll is the interval between attempts to verify a reset step.
+	verifyPoll = 5 * time.Second
+)
+
+// resetStep is a step of the device reset.
+type resetStep struct {
+	name    string
+	timeout time.Duration
+	apply   func(ctx context.Context, bdut *bindpb.Device, r resolver) error
+	verify  *bindpb.Verification
+}
+
+// resetSteps returns the steps of the reset of the device: the cli, the
+// gnmi_set_file and the gribi_flush of the configs, each of which does nothing
+// if unset, followed by the ordered steps.
+func resetSteps(bdut *bindpb.Device) []*resetStep {
+	steps := []*resetStep{
+		{name: "cli", timeout: resetStepTimeout, apply: resetCLI},
+		{name: "gnmi_set_file", timeout: resetStepTimeout, apply: resetGNMI},
+		{name: "gribi_flush", timeout: resetStepTimeout, apply: resetGRIBI},
+	}
+	for i, s := range bdut.GetConfig().GetSteps() {
+		step := &resetStep{
+			name:    stepName(i, s),
+			timeout: resetStepTimeout,
+			apply:   applyStep(s),
+			verify:  s.GetVerify(),
+		}
+		if s.GetTimeout() > 0 {
+			step.timeout = time.Duration(s.GetTimeout()) * time.Second
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// stepName returns the name of the i-th reset step, counting from 0.
+func stepName(i int, s *bindpb.ResetStep) string {
+	if s.GetName() != "" {
+		return s.GetName()
+	}
+	return fmt.Sprintf("step %d", i+1)
+}
+
+// applyStep returns the function which applies the config of the step.
+func applyStep(s *bindpb.ResetStep) func(context.Context, *bindpb.Device, resolver) error {
+	return func(ctx context.Context, bdut *bindpb.Device, r resolver) error {
+		switch c := s.GetConfig().(type) {
+		case *bindpb.ResetStep_Cli:
+			return sendCLI(ctx, bdut, r, string(c.Cli))
+		case *bindpb.ResetStep_CliFile:
+			conf, err := readCLI(c.CliFile)
+			if err != nil {
+				return err
+			}
+			return sendCLI(ctx, bdut, r, conf)
+		case *bindpb.ResetStep_GnmiSetFile:
+			req, err := readGNMI(c.GnmiSetFile)
+			if err != nil {
+				return err
+			}
+			return setGNMI(ctx, bdut, r, req)
+		case *bindpb.ResetStep_GribiFlush:
+			if !c.GribiFlush {
+				return nil
+			}
+			return flushGRIBI(ctx, bdut, r)
+		}
+		return nil
+	}
+}
+
+// resetDevice runs the reset steps of the device in order, each within its
+// timeout.  The error identifies the step which failed.
+func resetDevice(ctx context.Context, bdut *bindpb.Device, r resolver) error {
+	for _, step := range resetSteps(bdut) {
+		if err := step.run(ctx, bdut, r); err != nil {
+			return fmt.Errorf("reset step %q failed: %w", step.name, err)
+		}
+	}
+	return nil
+}
+
+func (s *resetStep) run(ctx context.Context, bdut *bindpb.Device, r resolver) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	if err := s.apply(ctx, bdut, r); err != nil {
+		return err
+	}
+	if s.verify == nil {
+		return nil
+	}
+	return verifyGNMI(ctx, bdut, r, s.verify)
+}
+
+// readVerification reads the GetRequest and the expected state of the
+// verification.
+func readVerification(v *bindpb.Verification) (*gpb.GetRequest, gnmiState, error) {
+	data, err := os.ReadFile(v.GetGnmiGetFile())
+	if err != nil {
+		return nil, nil, err
+	}
+	req := &gpb.GetRequest{}
+	if err := prototext.Unmarshal(data, req); err != nil {
+		return nil, nil, fmt.Errorf("gnmi_get_file %s: %w", v.GetGnmiGetFile(), err)
+	}
+	data, err = os.ReadFile(v.GetWantFile())
+	if err != nil {
+		return nil, nil, err
+	}
+	resp := &gpb.GetResponse{}
+	if err := prototext.Unmarshal(data, resp); err != nil {
+		return nil, nil, fmt.Errorf("want_file %s: %w", v.GetWantFile(), err)
+	}
+	want, err := stateOf(resp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("want_file %s: %w", v.GetWantFile(), err)
+	}
+	return req, want, nil
+}
+
+// verifyGNMI gets the state of the device until it is the expected state or
+// the context is done.
+func verifyGNMI(ctx context.Context, bdut *bindpb.Device, r resolver, v *bindpb.Verification) error {
+	req, want, err := readVerification(v)
+	if err != nil {
+		return err
+	}
+	dialer, err := r.gnmi(bdut.GetName())
+	if err != nil {
+		return err
+	}
+	conn, err := dialer.dialGRPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	gnmi := gpb.NewGNMIClient(conn)
+	var last error
+	for {
+		resp, err := gnmi.Get(ctx, req)
+		var got gnmiState
+		if err == nil {
+			got, err = stateOf(resp)
+		}
+		switch {
+		case err != nil && (last == nil || ctx.Err() == nil):
+			last = fmt.Errorf("verification get failed: %w", err)
+		case err != nil:
+			// Keep the last state which was compared.
+		default:
+			diffs := diffState(want, got)
+			if len(diffs) == 0 {
+				return nil
+			}
+			last = fmt.Errorf("verification failed: state differs from %s:\n%s", v.GetWantFile(), strings.Join(diffs, "\n"))
+		}
+		select {
+		case <-ctx.Done():
+			return last
+		case <-time.After(verifyPoll):
+		}
+	}
+}
+
+// gnmiState is the state in a GetResponse: the values by path, regardless
+// of the notifications and the order they are in.
+type gnmiState map[string]*gpb.TypedValue
+
+// stateOf returns the state in a GetResponse.  Timestamps are ignored.
+func stateOf(resp *gpb.GetResponse) (gnmiState, error) {
+	s := make(gnmiState)
+	for _, n := range resp.GetNotification() {
+		for _, u := range n.GetUpdate() {
+			p, err := joinPath(n.GetPrefix(), u.GetPath())
+			if err != nil {
+				return nil, err
+			}
+			s[p] = u.GetVal()
+		}
+	}
+	return s, nil
+}
+
+// joinPath returns the string of the path under the prefix.
+func joinPath(prefix, path *gpb.Path) (string, error) {
+	elems := append(append([]*gpb.PathElem{}, prefix.GetElem()...), path.GetElem()...)
+	p, err := ygot.PathToString(&gpb.Path{Elem: elems})
+	if err != nil {
+		return "", err
+	}
+	origin := prefix.GetOrigin()
+	if origin == "" {
+		origin = path.GetOrigin()
+	}
+	if origin != "" {
+		p = origin + ":" + p
+	}
+	return p, nil
+}
+
+// diffState returns the paths which are missing, unexpected or have a
+// different value in the state got, sorted by path.
+func diffState(want, got gnmiState) []string {
+	var diffs []string
+	for p, w := range want {
+		g, ok := got[p]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("%s: missing, want %s", p, prototext.Format(w)))
+		case !equalValues(w, g):
+			diffs = append(diffs, fmt.Sprintf("%s: got %s, want %s", p, prototext.Format(g), prototext.Format(w)))
+		}
+	}
+	for p, g := range got {
+		if _, ok := want[p]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: unexpected %s", p, prototext.Format(g)))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+// equalValues returns true if the values are equal.  JSON values are equal if
+// they decode to the same value, regardless of their formatting and the order
+// of their members.
+func equalValues(want, got *gpb.TypedValue) bool {
+	wj, wok := jsonValue(want)
+	gj, gok := jsonValue(got)
+	if !wok || !gok {
+		return proto.Equal(want, got)
+	}
+	var wv, gv interface{}
+	if json.Unmarshal(wj, &wv) != nil || json.Unmarshal(gj, &gv) != nil {
+		return bytes.Equal(wj, gj)
+	}
+	return reflect.DeepEqual(wv, gv)
+}
+
+// jsonValue returns the JSON of a json_val or json_ietf_val.
+func jsonValue(v *gpb.TypedValue) ([]byte, bool) {
+	switch v.GetValue().(type) {
+	case *gpb.TypedValue_JsonVal:
+		return v.GetJsonVal(), true
+	case *gpb.TypedValue_JsonIetfVal:
+		return v.GetJsonIetfVal(), true
+	}
+	return nil, false
+}
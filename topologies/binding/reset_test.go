@@ -0,0 +1,262 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	opb "github.com/openconfig/ondatra/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// barrier releases the callers of wait once n of them are waiting.
+type barrier struct {
+	mu   sync.Mutex
+	n    int
+	done chan struct{}
+}
+
+func newBarrier(n int) *barrier {
+	return &barrier{n: n, done: make(chan struct{})}
+}
+
+func (b *barrier) wait(ctx context.Context) error {
+	b.mu.Lock()
+	if b.n--; b.n == 0 {
+		close(b.done)
+	}
+	b.mu.Unlock()
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fakeResetGNMI keeps the hostname of the last Set, which Get returns.  If
+// barrier is set, each Set waits at it.
+type fakeResetGNMI struct {
+	gpb.UnimplementedGNMIServer
+	barrier *barrier
+
+	mu       sync.Mutex
+	hostname string
+}
+
+func (s *fakeResetGNMI) Set(ctx context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	if s.barrier != nil {
+		if err := s.barrier.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range req.GetUpdate() {
+		s.hostname = u.GetVal().GetStringVal()
+	}
+	return &gpb.SetResponse{}, nil
+}
+
+func (s *fakeResetGNMI) Get(context.Context, *gpb.GetRequest) (*gpb.GetResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &gpb.GetResponse{Notification: []*gpb.Notification{{
+		Timestamp: time.Now().UnixNano(),
+		Update: []*gpb.Update{{
+			Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: s.hostname}},
+		}},
+	}}}, nil
+}
+
+func newFakeResetGNMI(t *testing.T, s *fakeResetGNMI) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	gpb.RegisterGNMIServer(srv, s)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+// writeResetFiles writes the SetRequest of the hostname and the verification
+// which expects it, and returns the step.
+func writeResetFiles(t *testing.T, name, setHostname, wantHostname string) *bindpb.ResetStep {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"set.textproto":  `update { path { elem { name: "hostname" } } val { string_val: "` + setHostname + `" } }`,
+		"get.textproto":  `path { elem { name: "hostname" } } type: STATE`,
+		"want.textproto": `notification { timestamp: 1 update { val { string_val: "` + wantHostname + `" } } }`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Cannot write %s: %v", name, err)
+		}
+	}
+	return &bindpb.ResetStep{
+		Name:   name,
+		Config: &bindpb.ResetStep_GnmiSetFile{GnmiSetFile: filepath.Join(dir, "set.textproto")},
+		Verify: &bindpb.Verification{
+			GnmiGetFile: filepath.Join(dir, "get.textproto"),
+			WantFile:    filepath.Join(dir, "want.textproto"),
+		},
+	}
+}
+
+func TestResetDevice(t *testing.T) {
+	defer func(poll time.Duration) { verifyPoll = poll }(verifyPoll)
+	verifyPoll = 10 * time.Millisecond
+
+	ctx := context.Background()
+	good := writeResetFiles(t, "", "dut1", "dut1")
+	bad := writeResetFiles(t, "hostname", "dut1", "other")
+	bad.Timeout = 1
+
+	dev := &bindpb.Device{
+		Id:     "dut1",
+		Name:   "dut1.name",
+		Gnmi:   &bindpb.Options{Target: newFakeResetGNMI(t, &fakeResetGNMI{}), Insecure: true},
+		Config: &bindpb.Configs{Steps: []*bindpb.ResetStep{good}},
+	}
+	r := resolver{&bindpb.Binding{Duts: []*bindpb.Device{dev}}}
+	if err := resetDevice(ctx, dev, r); err != nil {
+		t.Errorf("resetDevice got error: %v", err)
+	}
+
+	dev.Config.Steps = append(dev.Config.Steps, bad)
+	err := resetDevice(ctx, dev, r)
+	if err == nil || !strings.Contains(err.Error(), `reset step "hostname" failed: verification failed`) {
+		t.Errorf("resetDevice got error %v, want the verification of step hostname failed", err)
+	}
+
+	dev.Config.Steps[0].Config = &bindpb.ResetStep_CliFile{CliFile: filepath.Join(t.TempDir(), "missing.cli")}
+	err = resetDevice(ctx, dev, r)
+	if err == nil || !strings.Contains(err.Error(), `reset step "step 1" failed`) {
+		t.Errorf("resetDevice got error %v, want step 1 failed", err)
+	}
+}
+
+func TestReset_Parallel(t *testing.T) {
+	// The Set of each DUT waits for the Set of the other, which only
+	// completes if the DUTs are reset in parallel.
+	bar := newBarrier(2)
+	newDUT := func(id string) *bindpb.Device {
+		step := writeResetFiles(t, "", id, id)
+		step.Timeout = 10
+		return &bindpb.Device{
+			Id:     id,
+			Name:   id + ".name",
+			Gnmi:   &bindpb.Options{Target: newFakeResetGNMI(t, &fakeResetGNMI{barrier: bar}), Insecure: true},
+			Config: &bindpb.Configs{Steps: []*bindpb.ResetStep{step}},
+		}
+	}
+	b := &staticBind{r: resolver{&bindpb.Binding{Duts: []*bindpb.Device{newDUT("dut1"), newDUT("dut2")}}}}
+	tb := &opb.Testbed{Duts: []*opb.Device{{Id: "dut1"}, {Id: "dut2"}}}
+	resv, err := reservation(tb, b.r)
+	if err != nil {
+		t.Fatalf("reservation got error: %v", err)
+	}
+	b.resv = resv
+
+	start := time.Now()
+	if err := b.reset(context.Background()); err != nil {
+		t.Errorf("reset got error: %v", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("reset took %v, want the DUTs reset in parallel", d)
+	}
+}
+
+func TestDiffState(t *testing.T) {
+	parse := func(text string) gnmiState {
+		t.Helper()
+		resp := &gpb.GetResponse{}
+		if err := prototext.Unmarshal([]byte(text), resp); err != nil {
+			t.Fatalf("Cannot parse %q: %v", text, err)
+		}
+		s, err := stateOf(resp)
+		if err != nil {
+			t.Fatalf("stateOf got error: %v", err)
+		}
+		return s
+	}
+	want := parse(`
+notification {
+  timestamp: 1
+  prefix { origin: "openconfig" elem { name: "system" } }
+  update { path { elem { name: "hostname" } } val { string_val: "dut1" } }
+  update { path { elem { name: "config" } } val { json_ietf_val: '{"a": 1, "b": [true, "x"]}' } }
+}`)
+
+	tests := []struct {
+		desc  string
+		got   string
+		diffs int
+	}{{
+		desc: "reordered",
+		got: `
+notification {
+  timestamp: 2
+  prefix { origin: "openconfig" }
+  update { path { elem { name: "system" } elem { name: "config" } } val { json_ietf_val: '{"b":[true,"x"],"a":1}' } }
+}
+notification {
+  timestamp: 3
+  prefix { origin: "openconfig" elem { name: "system" } }
+  update { path { elem { name: "hostname" } } val { string_val: "dut1" } }
+}`,
+	}, {
+		desc: "different",
+		got: `
+notification {
+  prefix { origin: "openconfig" elem { name: "system" } }
+  update { path { elem { name: "hostname" } } val { string_val: "dut2" } }
+  update { path { elem { name: "config" } } val { json_ietf_val: '{"a": 2, "b": [true, "x"]}' } }
+}`,
+		diffs: 2,
+	}, {
+		desc: "missing and unexpected",
+		got: `
+notification {
+  prefix { origin: "openconfig" elem { name: "system" } }
+  update { path { elem { name: "domain-name" } } val { string_val: "dut1" } }
+  update { path { elem { name: "config" } } val { json_ietf_val: '{"a": 1, "b": [true, "x"]}' } }
+}`,
+		diffs: 2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			diffs := diffState(want, parse(tt.got))
+			if len(diffs) != tt.diffs {
+				t.Errorf("diffState got %d diffs, want %d:\n%s", len(diffs), tt.diffs, strings.Join(diffs, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// envRef matches an environment variable reference ${NAME}.  The bare $NAME
+// form is not expanded, so that a "$" in a password stays as is.
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// fileRef is the prefix of an option whose value is read from a file.
+const fileRef = "file://"
+
+// expandEnv replaces the ${NAME} references in s by the environment
+// variables, which must be set.
+func expandEnv(s string) (string, error) {
+	var missing []string
+	s = envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envRef.FindStringSubmatch(ref)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if missing != nil {
+		return "", fmt.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+	return s, nil
+}
+
+// expandSecret expands the environment variables in s, then replaces a
+// file:// reference by the contents of the file, without the trailing newline.
+func expandSecret(s string) (string, error) {
+	s, err := expandEnv(s)
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimPrefix(s, fileRef)
+	if path == s {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read secret: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// secretOptions returns pointers to the secret fields of the options, which
+// may be read from a file.
+func secretOptions(o *bindpb.Options) []*string {
+	return []*string{
+		&o.Username,
+		&o.Password,
+		&o.SshPrivateKeyPassphrase,
+	}
+}
+
+// plainOptions returns pointers to the other string fields of the options,
+// which only expand environment variables.  The file options are paths, and
+// are not read here.
+func plainOptions(o *bindpb.Options) []*string {
+	return []*string{
+		&o.Target,
+		&o.CertFile,
+		&o.KeyFile,
+		&o.TrustBundleFile,
+		&o.TlsServerName,
+		&o.SshPrivateKeyFile,
+	}
+}
+
+// deviceOptions returns pointers to all the options of the device.
+func deviceOptions(dev *bindpb.Device) []**bindpb.Options {
+	return []**bindpb.Options{
+		&dev.Options,
+		&dev.Ssh,
+		&dev.Gnmi,
+		&dev.Gnoi,
+		&dev.Gnsi,
+		&dev.Gribi,
+		&dev.P4Rt,
+		&dev.Ixnetwork,
+		&dev.Otg,
+	}
+}
+
+// stepPaths returns pointers to the file paths of the reset step.
+func stepPaths(s *bindpb.ResetStep) []*string {
+	var paths []*string
+	switch c := s.GetConfig().(type) {
+	case *bindpb.ResetStep_CliFile:
+		paths = append(paths, &c.CliFile)
+	case *bindpb.ResetStep_GnmiSetFile:
+		paths = append(paths, &c.GnmiSetFile)
+	}
+	if v := s.GetVerify(); v != nil {
+		paths = append(paths, &v.GnmiGetFile, &v.WantFile)
+	}
+	return paths
+}
+
+// expandBinding expands the environment variables in the options and the
+// file references in their secrets, and the environment variables in the config file paths.
+func expandBinding(b *bindpb.Binding) error {
+	var errs allerrors
+	expandOptions := func(where string, o *bindpb.Options) {
+		if o == nil {
+			return
+		}
+		expand := func(p *string, f func(string) (string, error)) {
+			v, err := f(*p)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", where, err))
+				return
+			}
+			*p = v
+		}
+		for _, p := range secretOptions(o) {
+			expand(p, expandSecret)
+		}
+		for _, p := range plainOptions(o) {
+			expand(p, expandEnv)
+		}
+	}
+	expandPaths := func(where string, paths []string) {
+		for i, path := range paths {
+			v, err := expandEnv(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", where, err))
+				continue
+			}
+			paths[i] = v
+		}
+	}
+
+	expandOptions("binding options", b.Options)
+	for _, dev := range append(append([]*bindpb.Device{}, b.Duts...), b.Ates...) {
+		where := fmt.Sprintf("device %q", dev.Id)
+		for _, o := range deviceOptions(dev) {
+			expandOptions(where+" options", *o)
+		}
+		if c := dev.Config; c != nil {
+			expandPaths(where+" cli_file", c.CliFile)
+			expandPaths(where+" gnmi_set_file", c.GnmiSetFile)
+			for i, step := range c.Steps {
+				for _, p := range stepPaths(step) {
+					v, err := expandEnv(*p)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("%s reset step %q: %w", where, stepName(i, step), err))
+						continue
+					}
+					*p = v
+				}
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// readCredentials reads a credentials file, which is a binding with only the
+// IDs and the options of the devices.
+func readCredentials(path string) (*bindpb.Binding, error) {
+	in, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials file: %w", err)
+	}
+	creds := &bindpb.Binding{}
+	if err := prototext.Unmarshal(in, creds); err != nil {
+		return nil, fmt.Errorf("unable to parse credentials file: %w", err)
+	}
+	for _, dev := range append(append([]*bindpb.Device{}, creds.Duts...), creds.Ates...) {
+		if dev.Name != "" || len(dev.Ports) > 0 || dev.Config != nil || len(dev.Deviations) > 0 {
+			return nil, fmt.Errorf("credentials file device %q may only have an id and options", dev.Id)
+		}
+	}
+	return creds, nil
+}
+
+// mergeCredentials merges the options of the credentials over those of the
+// binding, for the binding and for each device by ID, the way merge does.
+func mergeCredentials(b, creds *bindpb.Binding) error {
+	mergeOptions := func(dst **bindpb.Options, src *bindpb.Options) {
+		if src == nil {
+			return
+		}
+		if *dst == nil {
+			*dst = &bindpb.Options{}
+		}
+		proto.Merge(*dst, src)
+	}
+	mergeDevices := func(kind string, devs, credDevs []*bindpb.Device) error {
+		for _, cdev := range credDevs {
+			var dev *bindpb.Device
+			for _, d := range devs {
+				if d.Id == cdev.Id {
+					dev = d
+					break
+				}
+			}
+			if dev == nil {
+				return fmt.Errorf("credentials for %s %q which is not in the binding", kind, cdev.Id)
+			}
+			dst, src := deviceOptions(dev), deviceOptions(cdev)
+			for i := range dst {
+				mergeOptions(dst[i], *src[i])
+			}
+		}
+		return nil
+	}
+
+	mergeOptions(&b.Options, creds.Options)
+	if err := mergeDevices("DUT", b.Duts, creds.Duts); err != nil {
+		return err
+	}
+	return mergeDevices("ATE", b.Ates, creds.Ates)
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binding
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bindpb "github.com/openconfig/featureprofiles/topologies/proto/binding"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestExpandSecret(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "password")
+	if err := os.WriteFile(secret, []byte("s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("FP_TEST_USER", "admin")
+	t.Setenv("FP_TEST_DIR", dir)
+
+	cases := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"pa$$word", "pa$$word"},
+		{"$FP_TEST_USER", "$FP_TEST_USER"},
+		{"${FP_TEST_USER}", "admin"},
+		{"${FP_TEST_USER}@${FP_TEST_USER}", "admin@admin"},
+		{"file://" + secret, "s3cret"},
+		{"file://${FP_TEST_DIR}/password", "s3cret"},
+	}
+	for _, c := range cases {
+		got, err := expandSecret(c.in)
+		if err != nil {
+			t.Errorf("expandSecret(%q) got error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("expandSecret(%q) got %q, want %q", c.in, got, c.want)
+		}
+	}
+
+	for _, in := range []string{"${FP_TEST_UNSET}", "file://" + filepath.Join(dir, "missing")} {
+		if got, err := expandSecret(in); err == nil {
+			t.Errorf("expandSecret(%q) got %q, want error", in, got)
+		}
+	}
+}
+
+func TestReadBinding(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("ate.password", "ate-secret\n")
+	t.Setenv("FP_TEST_DIR", dir)
+	t.Setenv("FP_TEST_PASSWORD", "global-secret")
+
+	bindingFile := write("lab.binding", `
+options { username: "admin" password: "${FP_TEST_PASSWORD}" }
+duts {
+  id: "dut"
+  name: "dut.name"
+  # Only the secrets are read from a file:// reference, not the paths.
+  gnmi { target: "dut.name:6030" key_file: "file://${FP_TEST_DIR}/ate.password" }
+  config { gnmi_set_file: "${FP_TEST_DIR}/dut.textproto" }
+}
+ates {
+  id: "ate"
+  name: "ate.name"
+  ixnetwork { username: "ixadmin" }
+}
+`)
+	credsFile := write("lab.credentials", `
+duts {
+  id: "dut"
+  options { username: "dutadmin" }
+  gnmi { password: "gnmi-secret" }
+}
+ates {
+  id: "ate"
+  ixnetwork { password: "file://${FP_TEST_DIR}/ate.password" }
+}
+`)
+
+	got, err := ReadBinding(bindingFile, credsFile)
+	if err != nil {
+		t.Fatalf("ReadBinding got error: %v", err)
+	}
+	want := &bindpb.Binding{
+		Options: &bindpb.Options{Username: "admin", Password: "global-secret"},
+		Duts: []*bindpb.Device{{
+			Id:      "dut",
+			Name:    "dut.name",
+			Options: &bindpb.Options{Username: "dutadmin"},
+			Gnmi:    &bindpb.Options{Target: "dut.name:6030", KeyFile: "file://" + filepath.Join(dir, "ate.password"), Password: "gnmi-secret"},
+			Config:  &bindpb.Configs{GnmiSetFile: []string{filepath.Join(dir, "dut.textproto")}},
+		}},
+		Ates: []*bindpb.Device{{
+			Id:        "ate",
+			Name:      "ate.name",
+			Ixnetwork: &bindpb.Options{Username: "ixadmin", Password: "ate-secret"},
+		}},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ReadBinding diff (-want +got):\n%s", diff)
+	}
+
+	// The merged options resolve like any other.
+	r := resolver{got}
+	d, err := r.gnmi("dut.name")
+	if err != nil {
+		t.Fatalf("gnmi resolver got error: %v", err)
+	}
+	if d.Username != "dutadmin" || d.Password != "gnmi-secret" {
+		t.Errorf("gnmi resolved to username %q password %q, want dutadmin and gnmi-secret", d.Username, d.Password)
+	}
+}
+
+func TestReadBinding_Errors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	bindingFile := write("lab.binding", `duts { id: "dut" name: "dut.name" }`)
+
+	cases := []struct {
+		desc, binding, creds, want string
+	}{{
+		desc:    "unset variable",
+		binding: write("unset.binding", `options { password: "${FP_TEST_UNSET}" }`),
+		want:    "FP_TEST_UNSET",
+	}, {
+		desc:    "missing secret file",
+		binding: write("missing.binding", `duts { id: "dut" ssh { password: "file:///no/such/secret" } }`),
+		want:    `device "dut" options`,
+	}, {
+		desc:    "unknown device",
+		binding: bindingFile,
+		creds:   write("unknown.credentials", `duts { id: "dut2" options { password: "x" } }`),
+		want:    `DUT "dut2" which is not in the binding`,
+	}, {
+		desc:    "credentials with ports",
+		binding: bindingFile,
+		creds:   write("ports.credentials", `duts { id: "dut" ports { id: "port1" name: "Ethernet1" } }`),
+		want:    "may only have an id and options",
+	}, {
+		desc:    "missing credentials",
+		binding: bindingFile,
+		creds:   filepath.Join(dir, "missing.credentials"),
+		want:    "unable to read credentials file",
+	}}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := ReadBinding(c.binding, c.creds)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("ReadBinding got error %v, want it to contain %q", err, c.want)
+			}
+		})
+	}
+}